@@ -1,6 +1,7 @@
 package company
 
 import (
+	"context"
 	"flag"
 	intigriti "github.com/hazcod/go-intigriti/pkg/api"
 	"github.com/sirupsen/logrus"
@@ -11,7 +12,7 @@ func isFaultyIP(ip net.IP) bool {
 	return ip == nil || ip.IsPrivate() || ip.IsLinkLocalMulticast() || ip.IsLinkLocalMulticast() || ip.IsLoopback()
 }
 
-func CheckIP(l *logrus.Logger, inti intigriti.Endpoint) {
+func CheckIP(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
 	if len(flag.Args()) != 3 {
 		l.Fatal("usage: inti company ip <ip-address>")
 	}
@@ -25,7 +26,7 @@ func CheckIP(l *logrus.Logger, inti intigriti.Endpoint) {
 		logger.Fatal("invalid ip address provided")
 	}
 
-	isResearcherIP, err := inti.IsKnownIP(ip)
+	isResearcherIP, err := inti.IsKnownIPWithContext(ctx, ip)
 	if err != nil {
 		logger.WithError(err).Fatal("could not verify IP address")
 	}
@@ -1,6 +1,7 @@
 package company
 
 import (
+	"context"
 	"flag"
 	"github.com/hazcod/go-intigriti/cmd/config"
 	"strings"
@@ -9,24 +10,32 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func Command(l *logrus.Logger, _ *config.Config, inti intigriti.Endpoint) {
+func Command(ctx context.Context, l *logrus.Logger, _ *config.Config, inti intigriti.Endpoint) {
 	if len(flag.Args()) < 2 {
-		l.Fatal("Missing subcommand. See: company <list,submissions>")
+		l.Fatal("Missing subcommand. See: company <list,submissions,program,submission>")
 	}
 
 	subCommand := strings.ToLower(flag.Arg(1))
 
 	switch subCommand {
 	case "ls", "list", "list-programs":
-		ListPrograms(l, inti)
+		ListPrograms(ctx, l, inti)
+		return
+
+	case "program", "prog", "p":
+		Program(ctx, l, inti)
 		return
 
 	case "sub", "submissions", "list-submissions":
-		ListSubmissions(l, inti)
+		ListSubmissions(ctx, l, inti)
+		return
+
+	case "submission":
+		Submission(ctx, l, inti)
 		return
 
 	case "check-ip", "ip":
-		CheckIP(l, inti)
+		CheckIP(ctx, l, inti)
 		return
 
 	case "auth":
@@ -34,6 +43,6 @@ func Command(l *logrus.Logger, _ *config.Config, inti intigriti.Endpoint) {
 		return
 
 	default:
-		l.Fatalf("Unknown subcommand '%s'. See: company <list,submissions>", subCommand)
+		l.Fatalf("Unknown subcommand '%s'. See: company <list,submissions,program,submission>", subCommand)
 	}
 }
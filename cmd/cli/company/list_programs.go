@@ -1,19 +1,20 @@
 package company
 
 import (
+	"context"
 	intigriti "github.com/hazcod/go-intigriti/pkg/api"
 	"github.com/sirupsen/logrus"
 )
 
-func ListPrograms(l *logrus.Logger, inti intigriti.Endpoint) {
+func ListPrograms(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
 	l.Info("Listing company programs")
 
-	programs, err := inti.GetPrograms()
+	programs, err := inti.GetProgramsWithContext(ctx)
 	if err != nil {
 		l.WithError(err).Fatal("could not list programs")
 	}
 
 	for _, program := range programs {
-		l.Infof("- %s (type %s, status %s, handle %s)", program.Name, program.Type.Value, program.Status.Value, program.Handle)
+		l.Infof("- %s (type %s, status %s, handle %s)", program.Name, program.Type, program.Status, program.Handle)
 	}
 }
@@ -1,10 +1,15 @@
 package company
 
 import (
+	"cmp"
+	"context"
+	"flag"
 	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/hazcod/go-intigriti/pkg/cvss"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"os"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +20,11 @@ type Filter struct {
 	Status     string
 	Researcher string
 	Assignee   string
+	// only submissions with a CVSS base score of at least MinScore are kept when set
+	MinScore float64
+	Rating   string
+	// sort on "score", highest first
+	Sort string
 }
 
 func CreateFilter(l *logrus.Logger, args []string) Filter {
@@ -59,12 +69,51 @@ func (f *Filter) Add(filter string) error {
 	case "assignee":
 		f.Assignee = filterVal
 		return nil
+	case "minscore":
+		minScore, err := strconv.ParseFloat(filterVal, 64)
+		if err != nil || minScore < 0 || minScore > 10 {
+			return errors.New("invalid minscore: " + filterVal + " , use a CVSS score between 0.0 and 10.0")
+		}
+		f.MinScore = minScore
+		return nil
+	case "rating":
+		if _, err := cvss.ParseRating(filterVal); err != nil {
+			return err
+		}
+		f.Rating = filterVal
+		return nil
+	case "sort":
+		if !strings.EqualFold(filterVal, "score") {
+			return errors.New("unknown sort: " + filterVal + " , only score is supported")
+		}
+		f.Sort = strings.ToLower(filterVal)
+		return nil
 	default:
 		return errors.New("unknown filter: " + filterName)
 	}
 }
 
+// submissionScore returns the CVSS base score of the submission, or -1 when it was not scored with a valid vector
+func submissionScore(sub intigriti.Submission) float64 {
+	vector, err := sub.CVSS()
+	if err != nil || vector == nil {
+		return -1
+	}
+
+	return vector.BaseScore()
+}
+
+// Filter removes the submissions which do not match every filter and sorts the remaining ones
+// the status is compared with the display value returned by the API, ignoring case
 func (f *Filter) Filter(submissions *[]intigriti.Submission) {
+	type scoredSubmission struct {
+		submission intigriti.Submission
+		score      float64
+	}
+
+	// the vector of every submission is parsed once, not on every comparison while sorting
+	filtered := make([]scoredSubmission, 0, len(*submissions))
+
 	for _, sub := range *submissions {
 		if f.Code != "" && sub.Code != f.Code {
 			continue
@@ -86,21 +135,45 @@ func (f *Filter) Filter(submissions *[]intigriti.Submission) {
 			continue
 		}
 
-		if f.Status != "" && sub.State.Status.Value != f.Status {
+		if f.Status != "" && !strings.EqualFold(sub.State.Status.String(), f.Status) {
 			continue
 		}
+
+		score := submissionScore(sub)
+
+		if f.MinScore > 0 && score < f.MinScore {
+			continue
+		}
+
+		if f.Rating != "" && (score < 0 || !strings.EqualFold(cvss.RatingOf(score).String(), f.Rating)) {
+			continue
+		}
+
+		filtered = append(filtered, scoredSubmission{submission: sub, score: score})
+	}
+
+	if f.Sort == "score" {
+		slices.SortStableFunc(filtered, func(a, b scoredSubmission) int {
+			return cmp.Compare(b.score, a.score)
+		})
+	}
+
+	*submissions = make([]intigriti.Submission, 0, len(filtered))
+	for _, sub := range filtered {
+		*submissions = append(*submissions, sub.submission)
 	}
 }
 
-func ListSubmissions(l *logrus.Logger, inti intigriti.Endpoint) {
-	filter := CreateFilter(l, os.Args[4:])
+func ListSubmissions(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	// the filters follow "company submissions", flag.Args skips global flags such as -config which os.Args does not
+	filter := CreateFilter(l, flag.Args()[2:])
 
 	l.Info("Listing company submissions")
 
 	programIDs := make([]string, 0)
 
 	if strings.TrimSpace(filter.Program) == "" || filter.Program == "*" {
-		programs, err := inti.GetPrograms()
+		programs, err := inti.GetProgramsWithContext(ctx)
 		if err != nil {
 			l.WithError(err).Fatal("could not list programs")
 		}
@@ -118,7 +191,7 @@ func ListSubmissions(l *logrus.Logger, inti intigriti.Endpoint) {
 	l.WithField("programs", len(programIDs)).Debug("retrieving submissions")
 
 	for _, programID := range programIDs {
-		pSubmissions, err := inti.GetProgramSubmissions(programID)
+		pSubmissions, err := inti.GetProgramSubmissionsWithContext(ctx, programID)
 		if err != nil {
 			l.WithError(err).WithField("program_id", pSubmissions).Error("could not list submissions")
 			continue
@@ -133,9 +206,15 @@ func ListSubmissions(l *logrus.Logger, inti intigriti.Endpoint) {
 	l.WithField("submissions", len(submissions)).Debug("filtered submissions")
 
 	for _, subm := range submissions {
+		score := ""
+		if subScore := submissionScore(subm); subScore >= 0 {
+			score = strconv.FormatFloat(subScore, 'f', 1, 64)
+		}
+
 		l.WithFields(logrus.Fields{
-			"state":      subm.State.Status.Value,
+			"state":      subm.State.Status.String(),
 			"severity":   subm.Severity.Value,
+			"score":      score,
 			"assignee":   subm.Assignee.Username,
 			"researcher": subm.Submitter.UserName,
 			"code":       subm.Code,
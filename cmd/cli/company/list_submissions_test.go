@@ -0,0 +1,34 @@
+package company
+
+import (
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	submission := func(code, status, vector string) intigriti.Submission {
+		sub := intigriti.Submission{Code: code}
+		sub.State.Status = intigriti.EnumValue[intigriti.SubmissionStatus]{ID: intigriti.SubmissionStatusTriage, Value: status}
+		sub.Severity.Vector = vector
+		return sub
+	}
+
+	submissions := []intigriti.Submission{
+		submission("medium", "Triage", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:L/I:N/A:N"),
+		submission("unscored", "Triage", ""),
+		submission("other-status", "Pending", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"),
+		submission("critical", "triage", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"),
+	}
+
+	filter := Filter{Status: "TRIAGE", Sort: "score"}
+	filter.Filter(&submissions)
+
+	var codes []string
+	for _, sub := range submissions {
+		codes = append(codes, sub.Code)
+	}
+
+	if len(codes) != 3 || codes[0] != "critical" || codes[1] != "medium" || codes[2] != "unscored" {
+		t.Errorf("unexpected filtered submissions %v", codes)
+	}
+}
@@ -0,0 +1,103 @@
+package company
+
+import (
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+func Program(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	if len(flag.Args()) < 3 {
+		l.Fatal("Missing subcommand. See: company program <show,diff,activity>")
+	}
+
+	subCommand := strings.ToLower(flag.Arg(2))
+
+	switch subCommand {
+	case "show", "get":
+		ShowProgram(ctx, l, inti)
+		return
+
+	case "diff":
+		DiffProgram(ctx, l, inti)
+		return
+
+	case "activity", "activities":
+		ProgramActivity(ctx, l, inti)
+		return
+
+	default:
+		l.Fatalf("Unknown subcommand '%s'. See: company program <show,diff,activity>", subCommand)
+	}
+}
+
+// resolveProgramID returns the program identifier for the given identifier or handle
+func resolveProgramID(ctx context.Context, inti intigriti.Endpoint, idOrHandle string) (string, error) {
+	programs, err := inti.GetProgramsWithContext(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "could not list programs")
+	}
+
+	for _, program := range programs {
+		if program.ID == idOrHandle || strings.EqualFold(program.Handle, idOrHandle) {
+			return program.ID, nil
+		}
+	}
+
+	return "", errors.New("unknown program: " + idOrHandle)
+}
+
+func ShowProgram(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	if len(flag.Args()) != 4 {
+		l.Fatal("usage: inti company program show <id|handle>")
+	}
+
+	programID, err := resolveProgramID(ctx, inti, flag.Arg(3))
+	if err != nil {
+		l.WithError(err).Fatal("could not find program")
+	}
+
+	program, err := inti.GetProgram(ctx, programID)
+	if err != nil {
+		l.WithError(err).Fatal("could not retrieve program")
+	}
+
+	l.Infof("%s (handle %s, id %s)", program.Name, program.Handle, program.ID)
+	l.Infof("type %s, status %s, confidentiality %s", program.Type, program.Status, program.ConfidentialityLevel)
+	l.Infof("details: %s", program.WebLinks.Details)
+
+	l.Infof("Domains (version %s)", program.Domains.ID)
+	for _, domain := range program.Domains.Content {
+		scope := "in scope"
+		if !domain.InScope() {
+			scope = "out of scope"
+		}
+
+		l.Infof("- %s (%s, %s, %s, id %s)", domain.Endpoint, domain.Type.Value, domain.Tier.Value, scope, domain.ID)
+		if domain.Description != "" {
+			l.Infof("    %s", domain.Description)
+		}
+	}
+
+	roe := program.RulesOfEngagement.Content
+
+	l.Infof("Rules of engagement (version %s)", program.RulesOfEngagement.ID)
+	l.Infof("safe harbour: %t", roe.SafeHarbour)
+	l.Infof("testing requirements: intigriti.me accounts %t, automated tooling %s, user agent '%s', request header '%s'",
+		roe.TestingRequirements.IntigritiMe, roe.TestingRequirements.AutomatedTooling.Value,
+		roe.TestingRequirements.UserAgent, roe.TestingRequirements.RequestHeader)
+	for _, line := range strings.Split(roe.Description, "\n") {
+		l.Info("    " + line)
+	}
+
+	l.Info("Bounty tables")
+	for _, table := range program.BountyTables {
+		l.Infof("- %s", table.Tier.Value)
+		for _, bounty := range table.Bounties {
+			l.Infof("    %s: %.2f %s", bounty.Severity.Value, bounty.Amount.Value, bounty.Amount.Currency)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package company
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/sirupsen/logrus"
+	"slices"
+	"time"
+)
+
+func ProgramActivity(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	const usage = "usage: inti company program activity [-since 24h] [-follow] [-interval 1m] <id|handle>"
+
+	flags := flag.NewFlagSet("activity", flag.ExitOnError)
+	sinceDuration := flags.Duration("since", 7*24*time.Hour, "How far back to list activity.")
+	follow := flags.Bool("follow", false, "Keep polling for new activity until interrupted.")
+	interval := flags.Duration("interval", time.Minute, "How often to poll for new activity when following.")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if flags.NArg() != 1 || *interval <= 0 {
+		l.Fatal(usage)
+	}
+
+	programID, err := resolveProgramID(ctx, inti, flags.Arg(0))
+	if err != nil {
+		l.WithError(err).Fatal("could not find program")
+	}
+
+	tail := activityTail{since: time.Now().Add(-*sinceDuration), seen: make(map[string]bool)}
+
+	for {
+		var activities []intigriti.ProgramActivity
+
+		for activity, err := range inti.ProgramActivities(ctx, programID, tail.since, nil) {
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				l.WithError(err).Error("could not retrieve program activity")
+				break
+			}
+
+			activities = append(activities, activity)
+		}
+
+		for _, activity := range tail.next(activities) {
+			l.WithFields(logrus.Fields{
+				"at":         formatTimestamp(activity.CreatedAt),
+				"by":         activity.User.UserName,
+				"type":       activity.Type.Value,
+				"submission": activity.SubmissionCode,
+				"version":    activity.VersionID,
+			}).Info(activity.Description)
+		}
+
+		if !*follow {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// activityTail keeps track of the printed activities while polling
+// activities at the last seen timestamp are returned again by the next poll
+type activityTail struct {
+	since time.Time
+	seen  map[string]bool
+}
+
+// next returns the activities of the batch which were not returned before, from oldest to newest
+// the API does not document an order, so the batch is sorted before moving the since timestamp
+func (t *activityTail) next(activities []intigriti.ProgramActivity) []intigriti.ProgramActivity {
+	slices.SortStableFunc(activities, func(a, b intigriti.ProgramActivity) int {
+		return cmp.Compare(a.CreatedAt, b.CreatedAt)
+	})
+
+	var unseen []intigriti.ProgramActivity
+
+	for _, activity := range activities {
+		if t.seen[activity.ID] {
+			continue
+		}
+
+		if activityTime := time.Unix(int64(activity.CreatedAt), 0); activityTime.After(t.since) {
+			t.since = activityTime
+			t.seen = make(map[string]bool)
+		}
+
+		t.seen[activity.ID] = true
+		unseen = append(unseen, activity)
+	}
+
+	return unseen
+}
@@ -0,0 +1,40 @@
+package company
+
+import (
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestActivityTail(t *testing.T) {
+	activity := func(id string, createdAt int) intigriti.ProgramActivity {
+		return intigriti.ProgramActivity{ID: id, CreatedAt: createdAt}
+	}
+
+	ids := func(activities []intigriti.ProgramActivity) []string {
+		var result []string
+		for _, a := range activities {
+			result = append(result, a.ID)
+		}
+		return result
+	}
+
+	tail := activityTail{since: time.Unix(100, 0), seen: make(map[string]bool)}
+
+	// newest first, with two activities at the latest timestamp
+	first := tail.next([]intigriti.ProgramActivity{activity("c", 300), activity("d", 300), activity("b", 200), activity("a", 100)})
+	if !slices.Equal(ids(first), []string{"a", "b", "c", "d"}) {
+		t.Errorf("unexpected first batch %v", ids(first))
+	}
+
+	// the next poll returns the activities at the last timestamp again
+	second := tail.next([]intigriti.ProgramActivity{activity("e", 400), activity("d", 300), activity("c", 300)})
+	if !slices.Equal(ids(second), []string{"e"}) {
+		t.Errorf("unexpected second batch %v", ids(second))
+	}
+
+	if third := tail.next([]intigriti.ProgramActivity{activity("e", 400)}); len(third) != 0 {
+		t.Errorf("expected nothing new, got %v", ids(third))
+	}
+}
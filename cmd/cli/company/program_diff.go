@@ -0,0 +1,110 @@
+package company
+
+import (
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+const (
+	diffKindDomains = "domains"
+	diffKindRules   = "rules"
+)
+
+func DiffProgram(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	const usage = "usage: inti company program diff [-kind domains|rules] <id|handle> <fromVersion> <toVersion>"
+
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	kind := flags.String("kind", diffKindDomains, "What to compare: domains or rules (of engagement).")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if flags.NArg() != 3 {
+		l.Fatal(usage)
+	}
+
+	programID, err := resolveProgramID(ctx, inti, flags.Arg(0))
+	if err != nil {
+		l.WithError(err).Fatal("could not find program")
+	}
+
+	fromVersion, toVersion := flags.Arg(1), flags.Arg(2)
+
+	switch strings.ToLower(*kind) {
+	case diffKindDomains:
+		diffDomains(ctx, l, inti, programID, fromVersion, toVersion)
+	case diffKindRules:
+		diffRules(ctx, l, inti, programID, fromVersion, toVersion)
+	default:
+		l.Fatal(usage)
+	}
+}
+
+func diffDomains(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint, programID, fromVersion, toVersion string) {
+	from, err := inti.GetProgramDomains(ctx, programID, fromVersion)
+	if err != nil {
+		l.WithError(err).WithField("version", fromVersion).Fatal("could not retrieve domains")
+	}
+
+	to, err := inti.GetProgramDomains(ctx, programID, toVersion)
+	if err != nil {
+		l.WithError(err).WithField("version", toVersion).Fatal("could not retrieve domains")
+	}
+
+	diff := intigriti.DiffDomains(*from, *to)
+	if diff.IsEmpty() {
+		l.Info("no scope changes between both versions")
+		return
+	}
+
+	for _, domain := range diff.Added {
+		l.Infof("+ %s (%s, %s)", domain.Endpoint, domain.Type.Value, domain.Tier.Value)
+	}
+
+	for _, domain := range diff.Removed {
+		l.Infof("- %s (%s, %s)", domain.Endpoint, domain.Type.Value, domain.Tier.Value)
+	}
+
+	for _, change := range diff.Changed {
+		l.Infof("~ %s (%s)", change.To.Endpoint, change.To.Type.Value)
+
+		if change.From.Tier.ID != change.To.Tier.ID {
+			l.Infof("    tier: %s -> %s", change.From.Tier.Value, change.To.Tier.Value)
+		}
+
+		if change.From.Description != change.To.Description {
+			l.Infof("    description: '%s' -> '%s'", change.From.Description, change.To.Description)
+		}
+	}
+}
+
+func diffRules(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint, programID, fromVersion, toVersion string) {
+	from, err := inti.GetProgramRulesOfEngagement(ctx, programID, fromVersion)
+	if err != nil {
+		l.WithError(err).WithField("version", fromVersion).Fatal("could not retrieve rules of engagement")
+	}
+
+	to, err := inti.GetProgramRulesOfEngagement(ctx, programID, toVersion)
+	if err != nil {
+		l.WithError(err).WithField("version", toVersion).Fatal("could not retrieve rules of engagement")
+	}
+
+	diff := intigriti.DiffRulesOfEngagement(*from, *to)
+	if diff.IsEmpty() {
+		l.Info("no rules of engagement changes between both versions")
+		return
+	}
+
+	for _, change := range diff.Requirements {
+		l.Infof("~ %s: '%s' -> '%s'", change.Field, change.From, change.To)
+	}
+
+	for _, change := range diff.Description {
+		if change.Op == intigriti.DiffUnchanged {
+			continue
+		}
+
+		l.Infof("%s %s", change.Op, change.Line)
+	}
+}
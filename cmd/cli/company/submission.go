@@ -0,0 +1,185 @@
+package company
+
+import (
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+func Submission(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	if len(flag.Args()) < 3 {
+		l.Fatal("Missing subcommand. See: company submission <show,timeline,accept,close,archive,reopen,severity,domain,type,assign,unassign,reference,tag,untag,payouts,payout,message,note,feedback,attachments>")
+	}
+
+	subCommand := strings.ToLower(flag.Arg(2))
+
+	switch subCommand {
+	case "show", "get":
+		ShowSubmission(ctx, l, inti)
+		return
+
+	case "timeline", "events":
+		SubmissionTimeline(ctx, l, inti)
+		return
+
+	case "accept", "close", "archive", "reopen":
+		ChangeSubmissionState(ctx, l, inti, subCommand)
+		return
+
+	case "assign":
+		AssignSubmission(ctx, l, inti)
+		return
+
+	case "unassign":
+		UnassignSubmission(ctx, l, inti)
+		return
+
+	case "reference", "ref":
+		SetSubmissionReference(ctx, l, inti)
+		return
+
+	case "tag", "untag":
+		TagSubmission(ctx, l, inti, subCommand)
+		return
+
+	case "payouts":
+		ListSubmissionPayouts(ctx, l, inti)
+		return
+
+	case "payout":
+		CreateSubmissionPayout(ctx, l, inti)
+		return
+
+	case "message", "note", "feedback":
+		PostSubmissionMessage(ctx, l, inti, subCommand)
+		return
+
+	case "attachments":
+		DownloadSubmissionAttachments(ctx, l, inti)
+		return
+
+	case "severity":
+		SetSubmissionSeverity(ctx, l, inti)
+		return
+
+	case "domain":
+		SetSubmissionDomain(ctx, l, inti)
+		return
+
+	case "type":
+		SetSubmissionType(ctx, l, inti)
+		return
+
+	default:
+		l.Fatalf("Unknown subcommand '%s'. See: company submission <show,timeline,accept,close,archive,reopen,severity,domain,type,assign,unassign,reference,tag,untag,payouts,payout,message,note,feedback,attachments>", subCommand)
+	}
+}
+
+// formatTimestamp formats the unix timestamps returned by the API
+func formatTimestamp(timestamp int) string {
+	if timestamp == 0 {
+		return "-"
+	}
+
+	return time.Unix(int64(timestamp), 0).Format(time.RFC3339)
+}
+
+// logText logs every line of a multiline text, indented
+func logText(l *logrus.Logger, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		l.Info("    " + line)
+	}
+}
+
+func ShowSubmission(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	if len(flag.Args()) != 4 {
+		l.Fatal("usage: inti company submission show <code>")
+	}
+
+	submission, err := inti.GetSubmission(ctx, flag.Arg(3))
+	if err != nil {
+		l.WithError(err).Fatal("could not retrieve submission")
+	}
+
+	report := submission.Report
+
+	l.Infof("%s: %s", submission.Code, submission.Title)
+	l.Infof("status %s, severity %s", submission.State.Status, submission.Severity.Value)
+
+	if vector, err := submission.CVSS(); err != nil {
+		l.WithError(err).WithField("vector", submission.Severity.Vector).Warn("could not parse CVSS vector")
+	} else if vector != nil {
+		l.Infof("CVSS %.1f (%s) %s", vector.BaseScore(), vector.Rating(), vector)
+
+		for _, metric := range vector.Metrics() {
+			l.Debugf("  %s", metric)
+		}
+	}
+
+	l.Infof("researcher %s, assignee %s, created %s", submission.Submitter.UserName, submission.Assignee.Username, formatTimestamp(submission.CreatedAt))
+	l.Infof("type %s (%s, %s, id %d)", report.Type.Value, report.Type.Category, report.Type.CWE, report.Type.ID)
+	l.Infof("domain %s (%s, %s, id %s)", report.Domain.Value, report.Domain.Type.Value, report.Domain.Tier.Value, report.Domain.ID)
+	l.Infof("endpoint %s", report.EndpointVulnerableComponent)
+	l.Infof("details: %s", submission.WebLinks.Details)
+
+	if submission.InternalReference != nil {
+		l.Infof("internal reference %s %s", submission.InternalReference.Reference, submission.InternalReference.URL)
+	}
+
+	if len(submission.Tags) > 0 {
+		l.Infof("tags %s", strings.Join(submission.Tags, ", "))
+	}
+
+	l.Info("Description")
+	logText(l, report.Description)
+
+	l.Info("Impact")
+	logText(l, report.Impact)
+
+	l.Info("Proof of concept")
+	logText(l, report.ProofOfConcept)
+
+	if len(report.Attachments) > 0 {
+		l.Info("Attachments")
+		for _, attachment := range report.Attachments {
+			l.Infof("- %s (%s, %d bytes)", attachment.FileName, attachment.MimeType, attachment.Size)
+		}
+	}
+
+	if len(submission.Collaborators) > 0 {
+		l.Info("Collaborators")
+		for _, collaborator := range submission.Collaborators {
+			l.Infof("- %s (%.0f%%)", collaborator.UserName, collaborator.BountySplit)
+		}
+	}
+
+	if len(submission.Payouts) > 0 {
+		l.Info("Payouts")
+		for _, payout := range submission.Payouts {
+			l.Infof("- %s %.2f %s (%s, %s)", payout.Type.Value, payout.Amount.Value, payout.Amount.Currency,
+				payout.Status.Value, formatTimestamp(payout.CreatedAt))
+		}
+	}
+}
+
+func SubmissionTimeline(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	if len(flag.Args()) != 4 {
+		l.Fatal("usage: inti company submission timeline <code>")
+	}
+
+	events, err := inti.GetSubmissionEvents(ctx, flag.Arg(3))
+	if err != nil {
+		l.WithError(err).Fatal("could not retrieve submission events")
+	}
+
+	for _, event := range events {
+		l.WithFields(logrus.Fields{
+			"at":   formatTimestamp(event.CreatedAt),
+			"by":   event.User.UserName,
+			"type": event.Type.Value,
+		}).Info(event.Payload.Summary())
+	}
+}
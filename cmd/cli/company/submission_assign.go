@@ -0,0 +1,47 @@
+package company
+
+import (
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/sirupsen/logrus"
+)
+
+func AssignSubmission(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	if len(flag.Args()) != 5 {
+		l.Fatal("usage: inti company submission assign <code> <username|email>")
+	}
+
+	code := flag.Arg(3)
+	logger := l.WithField("code", code).WithField("user", flag.Arg(4))
+
+	users, err := inti.GetCompanyUsers(ctx)
+	if err != nil {
+		logger.WithError(err).Fatal("could not list company users")
+	}
+
+	user, err := intigriti.FindCompanyUser(users, flag.Arg(4))
+	if err != nil {
+		logger.WithError(err).Fatal("could not find user to assign")
+	}
+
+	if err := inti.AssignSubmission(ctx, code, user.UserID); err != nil {
+		logger.WithError(err).Fatal("could not assign submission")
+	}
+
+	logger.Infof("assigned submission to %s", user.Username)
+}
+
+func UnassignSubmission(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	if len(flag.Args()) != 4 {
+		l.Fatal("usage: inti company submission unassign <code>")
+	}
+
+	logger := l.WithField("code", flag.Arg(3))
+
+	if err := inti.UnassignSubmission(ctx, flag.Arg(3)); err != nil {
+		logger.WithError(err).Fatal("could not unassign submission")
+	}
+
+	logger.Info("cleared submission assignee")
+}
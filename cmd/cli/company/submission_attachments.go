@@ -0,0 +1,215 @@
+package company
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maximum length of a downloaded file name in bytes
+	maxFileNameLength = 200
+)
+
+var (
+	// file names which are reserved on Windows, regardless of their extension
+	reservedFileNames = []string{
+		"CON", "PRN", "AUX", "NUL",
+		"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+		"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+	}
+)
+
+// sanitizeFileName turns a researcher provided file name into a safe name for the local filesystem
+// any directory components are dropped, the fallback is used if nothing usable remains
+func sanitizeFileName(name, fallback string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	// leading dots hide files, trailing dots and spaces are dropped by Windows
+	name = strings.Trim(name, ". ")
+
+	if name == "" {
+		return fallback
+	}
+
+	baseName, _, _ := strings.Cut(name, ".")
+	for _, reserved := range reservedFileNames {
+		if strings.EqualFold(baseName, reserved) {
+			name = "_" + name
+			break
+		}
+	}
+
+	if len(name) > maxFileNameLength {
+		ext := filepath.Ext(name)
+		if len(ext) > maxFileNameLength/2 {
+			ext = ""
+		}
+
+		name = strings.ToValidUTF8(name[:maxFileNameLength-len(ext)], "") + ext
+	}
+
+	return name
+}
+
+// fileSHA256 returns the hex encoded sha256 digest of the file
+func fileSHA256(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// downloadedAttachment is the outcome of downloading an attachment
+type downloadedAttachment struct {
+	Path   string
+	SHA256 string
+	// Existing is set when an identical file was already downloaded before
+	Existing bool
+}
+
+// downloadAttachment downloads the attachment into the directory, verifying its size and hash
+// the file is only moved into place once verified, existing files are never overwritten
+func downloadAttachment(ctx context.Context, inti intigriti.Endpoint, code, dir string, attachment intigriti.SubmissionAttachment) (*downloadedAttachment, error) {
+	tmpFile, err := os.CreateTemp(dir, ".inti-download-*")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create temporary file")
+	}
+
+	defer os.Remove(tmpFile.Name())
+
+	hash := sha256.New()
+
+	written, err := inti.DownloadSubmissionAttachment(ctx, code, attachment.ID, io.MultiWriter(tmpFile, hash))
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if attachment.Size > 0 && written != attachment.Size {
+		return nil, errors.Errorf("size mismatch, expected %d bytes but got %d", attachment.Size, written)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if attachment.SHA256 != "" && !strings.EqualFold(checksum, attachment.SHA256) {
+		return nil, errors.Errorf("hash mismatch, expected %s but got %s", attachment.SHA256, checksum)
+	}
+
+	return placeDownload(tmpFile.Name(), dir, attachment, checksum)
+}
+
+// placeDownload links the verified download into the directory under the attachment file name, the caller removes the temporary file
+// another attachment may have the same name, in which case the attachment identifier is prepended
+// a file which is already there is kept when it is the same download, e.g. when running again
+func placeDownload(tmpPath, dir string, attachment intigriti.SubmissionAttachment, checksum string) (*downloadedAttachment, error) {
+	fileName := sanitizeFileName(attachment.FileName, attachment.ID)
+	candidates := []string{fileName, sanitizeFileName(attachment.ID+"-"+fileName, attachment.ID)}
+
+	for _, candidate := range candidates {
+		targetPath := filepath.Join(dir, candidate)
+
+		// linking fails when the file exists, unlike renaming which would replace a file created in the meantime
+		err := os.Link(tmpPath, targetPath)
+		if err == nil {
+			return &downloadedAttachment{Path: targetPath, SHA256: checksum}, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, errors.Wrap(err, "could not move download into place")
+		}
+
+		if existing, err := fileSHA256(targetPath); err == nil && existing == checksum {
+			return &downloadedAttachment{Path: targetPath, SHA256: checksum, Existing: true}, nil
+		}
+	}
+
+	return nil, errors.Errorf("refusing to overwrite the existing files %s", strings.Join(candidates, " and "))
+}
+
+func DownloadSubmissionAttachments(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	const usage = "usage: inti company submission attachments <code> [-dir <path>]"
+
+	flags := flag.NewFlagSet("attachments", flag.ExitOnError)
+	dir := flags.String("dir", ".", "The directory to download the attachments into, created if missing.")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if flags.NArg() == 0 {
+		l.Fatal(usage)
+	}
+
+	// allow the flags after the submission code too
+	code := flags.Arg(0)
+	_ = flags.Parse(flags.Args()[1:])
+
+	if flags.NArg() != 0 {
+		l.Fatal(usage)
+	}
+
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		l.WithError(err).Fatal("could not create download directory")
+	}
+
+	attachments, err := inti.GetSubmissionAttachments(ctx, code)
+	if err != nil {
+		l.WithError(err).WithField("code", code).Fatal("could not list attachments")
+	}
+
+	failed := 0
+
+	for _, attachment := range attachments {
+		logger := l.WithField("code", code).WithField("attachment", attachment.FileName)
+
+		downloaded, err := downloadAttachment(ctx, inti, code, *dir, attachment)
+		if err != nil {
+			logger.WithError(err).Error("could not download attachment")
+			failed++
+			continue
+		}
+
+		logger = logger.WithField("path", downloaded.Path).WithField("sha256", downloaded.SHA256)
+
+		if attachment.SHA256 == "" {
+			logger.Warn("no hash was returned for the attachment, only its size was verified")
+		}
+
+		if downloaded.Existing {
+			logger.Info("attachment was already downloaded")
+		} else {
+			logger.Info("downloaded attachment")
+		}
+	}
+
+	if failed > 0 {
+		l.Fatalf("could not download %d out of %d attachments", failed, len(attachments))
+	}
+}
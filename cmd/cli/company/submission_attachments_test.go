@@ -0,0 +1,75 @@
+package company
+
+import (
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := map[string]string{
+		"screenshot.png":         "screenshot.png",
+		"../../etc/passwd":       "passwd",
+		`..\..\windows\win.ini`:  "win.ini",
+		"/absolute/path/poc.txt": "poc.txt",
+		".bashrc":                "bashrc",
+		"..":                     "fallback",
+		"":                       "fallback",
+		"con.txt":                "_con.txt",
+		"a\x00b\nc.txt":          "a_b_c.txt",
+		`what?<is>this*.mp4`:     "what__is_this_.mp4",
+	}
+
+	for input, expected := range tests {
+		if actual := sanitizeFileName(input, "fallback"); actual != expected {
+			t.Errorf("sanitizeFileName(%q) = %q, expected %q", input, actual, expected)
+		}
+	}
+}
+
+func TestPlaceDownload(t *testing.T) {
+	dir := t.TempDir()
+	attachment := intigriti.SubmissionAttachment{ID: "1", FileName: "poc.txt"}
+
+	download := func(content string) (*downloadedAttachment, error) {
+		tmpPath := filepath.Join(dir, ".download")
+		if err := os.WriteFile(tmpPath, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		// like downloadAttachment, remove the temporary file once placed
+		defer os.Remove(tmpPath)
+
+		checksum, err := fileSHA256(tmpPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return placeDownload(tmpPath, dir, attachment, checksum)
+	}
+
+	// another attachment already took the name
+	if err := os.WriteFile(filepath.Join(dir, "poc.txt"), []byte("other"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := download("exploit")
+	if err != nil || first.Existing || filepath.Base(first.Path) != "1-poc.txt" {
+		t.Fatalf("unexpected first download %+v: %v", first, err)
+	}
+
+	// running again keeps the identical file
+	if again, err := download("exploit"); err != nil || !again.Existing || again.Path != first.Path {
+		t.Errorf("unexpected repeated download %+v: %v", again, err)
+	}
+
+	// a changed attachment never overwrites the earlier download
+	if _, err := download("changed"); err == nil {
+		t.Error("expected existing files not to be overwritten")
+	}
+
+	if b, _ := os.ReadFile(first.Path); string(b) != "exploit" {
+		t.Errorf("earlier download was overwritten with %q", b)
+	}
+}
@@ -0,0 +1,155 @@
+package company
+
+import (
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	defaultEditor = "vi"
+	// the hint and everything else below this line is stripped from text written in the editor
+	// a Markdown heading the user writes starts with '#' too, so only this exact line is a marker
+	editorScissors = "# ------------------------ >8 ------------------------"
+)
+
+// readText returns the text from the flag, the file ('-' for stdin) or otherwise from the user's editor
+func readText(text, file, hint string) (string, error) {
+	if text != "" {
+		return text, nil
+	}
+
+	if file == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", errors.Wrap(err, "could not read stdin")
+		}
+
+		return string(b), nil
+	}
+
+	if file != "" {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return "", errors.Wrap(err, "could not read file")
+		}
+
+		return string(b), nil
+	}
+
+	return editText(hint)
+}
+
+// editorCommand returns $VISUAL or $EDITOR split into its arguments, such as 'code --wait'
+// variables which are empty or only whitespace are skipped
+func editorCommand() []string {
+	for _, editor := range []string{os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if editorArgs := strings.Fields(editor); len(editorArgs) > 0 {
+			return editorArgs
+		}
+	}
+
+	return []string{defaultEditor}
+}
+
+// editText opens $VISUAL or $EDITOR on a temporary file and returns what was written
+func editText(hint string) (string, error) {
+	tmpFile, err := os.CreateTemp("", "inti-*.md")
+	if err != nil {
+		return "", errors.Wrap(err, "could not create temporary file")
+	}
+
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("\n\n" + editorScissors + "\n# " + hint + "\n" +
+		"# Do not modify or remove the line above, everything below it is ignored.\n")
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return "", errors.Wrap(err, "could not write temporary file")
+	}
+
+	editorArgs := editorCommand()
+	cmd := exec.Command(editorArgs[0], append(editorArgs[1:], tmpFile.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrap(err, "editor failed")
+	}
+
+	b, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		return "", errors.Wrap(err, "could not read temporary file")
+	}
+
+	return stripEditorHint(string(b)), nil
+}
+
+// stripEditorHint removes the scissors line and the hint below it from the edited text
+func stripEditorHint(text string) string {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		if strings.TrimRight(line, "\r") == editorScissors {
+			return strings.Join(lines[:i], "\n")
+		}
+	}
+
+	return text
+}
+
+// PostSubmissionMessage messages the researcher, adds an internal note or requests feedback
+func PostSubmissionMessage(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint, action string) {
+	usage := "usage: inti company submission " + action + " [-message <text> | -file <path|->] <code>"
+
+	flags := flag.NewFlagSet(action, flag.ExitOnError)
+	message := flags.String("message", "", "The text to post.")
+	file := flags.String("file", "", "Read the text from this file, or stdin for '-'. Opens $EDITOR if neither is given.")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if flags.NArg() != 1 {
+		l.Fatal(usage)
+	}
+
+	code := flags.Arg(0)
+	logger := l.WithField("code", code)
+
+	hint := "Write the message to the researcher of " + code + "."
+	if action == "note" {
+		hint = "Write the internal note for " + code + ", only visible to your company."
+	}
+
+	text, err := readText(*message, *file, hint)
+	if err != nil {
+		logger.WithError(err).Fatal("could not read text")
+	}
+
+	if text = strings.TrimSpace(text); text == "" {
+		logger.Fatal("empty text, nothing posted")
+	}
+
+	switch action {
+	case "message":
+		err = inti.PostSubmissionMessage(ctx, code, text)
+	case "note":
+		err = inti.PostSubmissionNote(ctx, code, text)
+	case "feedback":
+		err = inti.RequestSubmissionFeedback(ctx, code, text)
+	default:
+		logger.Fatalf("unknown submission action '%s'", action)
+	}
+
+	if err != nil {
+		logger.WithError(err).Fatalf("could not post %s", action)
+	}
+
+	logger.Infof("posted %s", action)
+}
@@ -0,0 +1,38 @@
+package company
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStripEditorHint(t *testing.T) {
+	edited := "# Steps\n\n## Reproduce\nOpen the page.\n\n" + editorScissors + "\n# Write the message.\n# ignored\n"
+
+	if text := stripEditorHint(edited); text != "# Steps\n\n## Reproduce\nOpen the page.\n" {
+		t.Errorf("unexpected text %q", text)
+	}
+
+	if text := stripEditorHint("# no hint left"); text != "# no hint left" {
+		t.Errorf("unexpected text %q", text)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		visual, editor string
+		expected       []string
+	}{
+		{"code --wait", "vim", []string{"code", "--wait"}},
+		{"  ", "nano", []string{"nano"}},
+		{" ", "\t", []string{defaultEditor}},
+	}
+
+	for _, test := range tests {
+		t.Setenv("VISUAL", test.visual)
+		t.Setenv("EDITOR", test.editor)
+
+		if actual := editorCommand(); !slices.Equal(actual, test.expected) {
+			t.Errorf("unexpected editor %q for VISUAL %q and EDITOR %q", actual, test.visual, test.editor)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package company
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/sirupsen/logrus"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func ListSubmissionPayouts(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	if len(flag.Args()) != 4 {
+		l.Fatal("usage: inti company submission payouts <code>")
+	}
+
+	payouts, err := inti.GetSubmissionPayouts(ctx, flag.Arg(3))
+	if err != nil {
+		l.WithError(err).Fatal("could not list payouts")
+	}
+
+	for _, payout := range payouts {
+		l.Infof("- %s %.2f %s (%s, %s)", payout.Type.Value, payout.Amount.Value, payout.Amount.Currency,
+			payout.Status.Value, formatTimestamp(payout.CreatedAt))
+
+		for _, recipient := range payout.Recipients {
+			l.Infof("    %s: %.2f %s (%.0f%%)", recipient.UserName, recipient.Amount.Value, recipient.Amount.Currency, recipient.Percentage)
+		}
+	}
+}
+
+// confirmAmount asks the user to type the amount again, unless it was confirmed with a flag
+func confirmAmount(l *logrus.Logger, amount float64, currency, confirmation string) bool {
+	if confirmation == "" {
+		l.Warnf("Type the amount (%.2f) again to award %.2f %s:", amount, amount, currency)
+
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			l.WithError(err).Error("could not read confirmation")
+			return false
+		}
+
+		confirmation = line
+	}
+
+	confirmed, err := strconv.ParseFloat(strings.TrimSpace(confirmation), 64)
+
+	return err == nil && confirmed == amount
+}
+
+func CreateSubmissionPayout(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	const usage = "usage: inti company submission payout [-type bounty|bonus] -amount <amount> -currency <EUR> -message <text> [-confirm <amount>] <code>"
+
+	flags := flag.NewFlagSet("payout", flag.ExitOnError)
+	typeName := flags.String("type", "bounty", "The payout type: bounty or bonus.")
+	amount := flags.Float64("amount", 0, "Required: the total amount to award.")
+	currency := flags.String("currency", "", "Required: the currency of the amount, such as EUR.")
+	message := flags.String("message", "", "Required: the message to send to the researcher.")
+	confirmation := flags.String("confirm", "", "The amount again, to confirm without prompting.")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if flags.NArg() != 1 || *amount <= 0 || *currency == "" || strings.TrimSpace(*message) == "" {
+		l.Fatal(usage)
+	}
+
+	payoutType, err := intigriti.ParsePayoutType(*typeName)
+	if err != nil {
+		l.WithError(err).Fatal(usage)
+	}
+
+	code := flags.Arg(0)
+	logger := l.WithField("code", code)
+
+	if !confirmAmount(l, *amount, *currency, *confirmation) {
+		logger.Fatal("amount not confirmed, no payout created")
+	}
+
+	payout, err := inti.CreateSubmissionPayout(ctx, code, intigriti.PayoutRequest{
+		Type:     payoutType,
+		Amount:   *amount,
+		Currency: *currency,
+		Message:  *message,
+	})
+	if err != nil {
+		logger.WithError(err).Fatal("could not create payout")
+	}
+
+	logger.Infof("created %s payout of %.2f %s (%s)", payout.Type.Value, payout.Amount.Value, payout.Amount.Currency, payout.Status.Value)
+}
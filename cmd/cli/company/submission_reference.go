@@ -0,0 +1,68 @@
+package company
+
+import (
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/sirupsen/logrus"
+)
+
+func SetSubmissionReference(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	const usage = "usage: inti company submission reference [-url <url>] <code> <reference> | reference -clear <code>"
+
+	flags := flag.NewFlagSet("reference", flag.ExitOnError)
+	referenceURL := flags.String("url", "", "Link to the internal ticket.")
+	clearReference := flags.Bool("clear", false, "Remove the internal reference.")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if *clearReference {
+		if flags.NArg() != 1 {
+			l.Fatal(usage)
+		}
+
+		logger := l.WithField("code", flags.Arg(0))
+
+		if err := inti.ClearSubmissionInternalReference(ctx, flags.Arg(0)); err != nil {
+			logger.WithError(err).Fatal("could not clear internal reference")
+		}
+
+		logger.Info("cleared internal reference")
+		return
+	}
+
+	if flags.NArg() != 2 {
+		l.Fatal(usage)
+	}
+
+	logger := l.WithField("code", flags.Arg(0)).WithField("reference", flags.Arg(1))
+
+	reference := intigriti.InternalReference{Reference: flags.Arg(1), URL: *referenceURL}
+	if err := inti.SetSubmissionInternalReference(ctx, flags.Arg(0), reference); err != nil {
+		logger.WithError(err).Fatal("could not set internal reference")
+	}
+
+	logger.Info("set internal reference")
+}
+
+// TagSubmission adds or removes tags on a submission
+func TagSubmission(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint, action string) {
+	if len(flag.Args()) < 5 {
+		l.Fatalf("usage: inti company submission %s <code> <tag>...", action)
+	}
+
+	code, tags := flag.Arg(3), flag.Args()[4:]
+	logger := l.WithField("code", code).WithField("tags", tags)
+
+	var err error
+	if action == "untag" {
+		err = inti.RemoveSubmissionTags(ctx, code, tags...)
+	} else {
+		err = inti.AddSubmissionTags(ctx, code, tags...)
+	}
+
+	if err != nil {
+		logger.WithError(err).Fatal("could not change tags")
+	}
+
+	logger.Info("changed tags")
+}
@@ -0,0 +1,55 @@
+package company
+
+import (
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+// ChangeSubmissionState accepts, closes, archives or reopens a submission
+func ChangeSubmissionState(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint, action string) {
+	usage := "usage: inti company submission " + action + " -message <text> <code>"
+	if action == "close" {
+		usage = "usage: inti company submission close -reason <duplicate,out-of-scope,informative,...> -message <text> <code>"
+	}
+
+	flags := flag.NewFlagSet(action, flag.ExitOnError)
+	message := flags.String("message", "", "Required: the message to send to the researcher.")
+	reasonName := flags.String("reason", "", "Required when closing: the close reason.")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if flags.NArg() != 1 || strings.TrimSpace(*message) == "" {
+		l.Fatal(usage)
+	}
+
+	code := flags.Arg(0)
+	logger := l.WithField("code", code)
+
+	var err error
+
+	switch action {
+	case "accept":
+		err = inti.AcceptSubmission(ctx, code, *message)
+	case "close":
+		reason, parseErr := intigriti.ParseCloseReason(*reasonName)
+		if parseErr != nil {
+			logger.WithError(parseErr).Fatal(usage)
+		}
+
+		err = inti.CloseSubmission(ctx, code, reason, *message)
+	case "archive":
+		err = inti.ArchiveSubmission(ctx, code, *message)
+	case "reopen":
+		err = inti.ReopenSubmission(ctx, code, *message)
+	default:
+		logger.Fatalf("unknown submission action '%s'", action)
+	}
+
+	if err != nil {
+		logger.WithError(err).Fatalf("could not %s submission", action)
+	}
+
+	logger.Infof("submission %s successful", action)
+}
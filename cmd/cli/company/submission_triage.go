@@ -0,0 +1,88 @@
+package company
+
+import (
+	"context"
+	"flag"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+// updateSubmissions applies the update to every given submission code
+// all submissions are tried before exiting when any of them failed
+func updateSubmissions(l *logrus.Logger, codes []string, action string, update func(code string) error) {
+	failed := 0
+
+	for _, code := range codes {
+		logger := l.WithField("code", code)
+
+		if err := update(code); err != nil {
+			logger.WithError(err).Errorf("could not change %s", action)
+			failed++
+			continue
+		}
+
+		logger.Infof("changed %s", action)
+	}
+
+	if failed > 0 {
+		l.Fatalf("could not change %s of %d out of %d submissions", action, failed, len(codes))
+	}
+}
+
+func SetSubmissionSeverity(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	const usage = "usage: inti company submission severity [-level <low,medium,high,critical,...>] [-vector <cvss>] <code>..."
+
+	flags := flag.NewFlagSet("severity", flag.ExitOnError)
+	level := flags.String("level", "", "The new severity, derived from the vector if omitted.")
+	vector := flags.String("vector", "", "The new CVSS 3.x or 4.0 vector.")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if flags.NArg() == 0 || (*level == "" && *vector == "") {
+		l.Fatal(usage)
+	}
+
+	var severity intigriti.Severity
+	if *level != "" {
+		var err error
+		if severity, err = intigriti.ParseSeverity(*level); err != nil {
+			l.WithError(err).Fatal(usage)
+		}
+	}
+
+	updateSubmissions(l, flags.Args(), "severity", func(code string) error {
+		return inti.SetSubmissionSeverity(ctx, code, severity, *vector)
+	})
+}
+
+func SetSubmissionDomain(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	const usage = "usage: inti company submission domain -domain <domain-id> <code>..."
+
+	flags := flag.NewFlagSet("domain", flag.ExitOnError)
+	domainID := flags.String("domain", "", "Required: the program domain identifier, see: company program show.")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if flags.NArg() == 0 || strings.TrimSpace(*domainID) == "" {
+		l.Fatal(usage)
+	}
+
+	updateSubmissions(l, flags.Args(), "domain", func(code string) error {
+		return inti.SetSubmissionDomain(ctx, code, *domainID)
+	})
+}
+
+func SetSubmissionType(ctx context.Context, l *logrus.Logger, inti intigriti.Endpoint) {
+	const usage = "usage: inti company submission type -type <type-id> <code>..."
+
+	flags := flag.NewFlagSet("type", flag.ExitOnError)
+	typeID := flags.Int("type", 0, "Required: the vulnerability type identifier, see the type id of: company submission show.")
+	_ = flags.Parse(flag.Args()[3:])
+
+	if flags.NArg() == 0 || *typeID <= 0 {
+		l.Fatal(usage)
+	}
+
+	updateSubmissions(l, flags.Args(), "vulnerability type", func(code string) error {
+		return inti.SetSubmissionVulnerabilityType(ctx, code, *typeID)
+	})
+}
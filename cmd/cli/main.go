@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"github.com/hazcod/go-intigriti/cmd/cli/company"
+	"github.com/hazcod/go-intigriti/cmd/cli/webhook"
 	"github.com/hazcod/go-intigriti/cmd/config"
 	intigriti "github.com/hazcod/go-intigriti/pkg/api"
 	apiConfig "github.com/hazcod/go-intigriti/pkg/config"
 	"github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
 	"strings"
 )
 
@@ -17,6 +21,10 @@ func main() {
 	logLevelStr := flag.String("log", "", "Log level.")
 	flag.Parse()
 
+	// cancel any in-flight API calls when interrupted
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
 	if *logLevelStr != "" {
 		logLevel, err := logrus.ParseLevel(*logLevelStr)
 		if err != nil {
@@ -32,10 +40,6 @@ func main() {
 		logger.Fatalf("could not load configuration: %s", err)
 	}
 
-	if err := cfg.Validate(); err != nil {
-		logger.WithError(err).Fatal("invalid configuration")
-	}
-
 	if cfg.Log.Level != "" && *logLevelStr == "" {
 		logLevel, err := logrus.ParseLevel(cfg.Log.Level)
 		if err != nil {
@@ -46,23 +50,57 @@ func main() {
 		logger.WithField("level", logLevel.String()).Debugf("log level set")
 	}
 
-	apiScopes := []string{"company_external_api", "core_platform:read"}
+	if len(flag.Args()) == 0 {
+		logger.Fatalf("no command provided. See: company, webhook")
+	}
+
+	command := strings.ToLower(flag.Args()[0])
+
+	// commands which do not talk to the Intigriti API and thus need no authentication
+	if command == "webhook" || command == "wh" {
+		webhook.Command(ctx, logger, cfg)
+		return
+	}
+
+	if err := cfg.Validate(); err != nil {
+		logger.WithError(err).Fatal("invalid configuration")
+	}
+
+	callback := apiConfig.CallbackConfig{
+		Host:               cfg.Auth.Callback.Host,
+		Ports:              cfg.Auth.Callback.Ports,
+		Path:               cfg.Auth.Callback.Path,
+		AllowEphemeralPort: cfg.Auth.Callback.EphemeralPort,
+	}
 
-	inti, err := intigriti.New(apiConfig.Config{
+	if cfg.Auth.Callback.SuccessPage != "" {
+		successPage, err := os.ReadFile(cfg.Auth.Callback.SuccessPage)
+		if err != nil {
+			logger.WithError(err).Fatal("could not read callback success page")
+		}
+
+		callback.SuccessPage = string(successPage)
+	}
+
+	inti, err := intigriti.NewWithContext(ctx, apiConfig.Config{
 		// our Intigriti API credentials
 		Credentials: struct {
 			ClientID     string
 			ClientSecret string
 		}{ClientID: cfg.Auth.ClientID, ClientSecret: cfg.Auth.ClientSecret},
-		APIScopes: apiScopes,
+		APIScopes: cfg.APIScopes(),
+
+		// CI and services use the client_credentials or static_token modes which never wait for a login
+		AuthMode:    apiConfig.AuthMode(cfg.Auth.Mode),
+		StaticToken: cfg.Auth.Token,
+
+		// over SSH or in containers the redirect URL is pasted on stdin instead
+		LoginMode: apiConfig.LoginMode(cfg.Auth.Login),
+		Callback:  callback,
 
 		// cache tokens as much as possible to reduce times we have to authenticate
-		TokenCache: &apiConfig.CachedToken{
-			RefreshToken: cfg.Cache.RefreshToken,
-			AccessToken:  cfg.Cache.AccessToken,
-			ExpiryDate:   cfg.Cache.ExpiryDate,
-			Type:         cfg.Cache.Type,
-		},
+		// every refreshed token is written back to our configuration file
+		TokenStore: cfg.TokenStore(logger, *configPath),
 
 		// use our logger and our logging levels
 		Logger: logger,
@@ -71,28 +109,13 @@ func main() {
 		logger.WithError(err).Fatal("could not initialize client")
 	}
 
-	token, err := inti.GetToken()
-	if err != nil {
-		logger.Fatalf("failed to cache token: %v", err)
-	}
-
-	if err := cfg.CacheAuth(logger, *configPath, token); err != nil {
-		logger.Fatalf("failed to cache token: %v", err)
-	}
-
 	logger.WithField("authenticated", inti.IsAuthenticated()).Debug("initialized client")
 
-	if len(flag.Args()) == 0 {
-		logger.Fatalf("no command provided. See: company")
-	}
-
-	command := strings.ToLower(flag.Args()[0])
-
-	switch strings.ToLower(command) {
+	switch command {
 	case "company", "c", "com":
-		company.Command(logger, cfg, inti)
+		company.Command(ctx, logger, cfg, inti)
 		return
 	default:
-		logger.Fatalf("unknown command '%s'. See: company", command)
+		logger.Fatalf("unknown command '%s'. See: company, webhook", command)
 	}
 }
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"flag"
+	"github.com/hazcod/go-intigriti/cmd/config"
+	"github.com/hazcod/go-intigriti/pkg/webhook"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// time we give in-flight webhooks to finish when shutting down
+	shutdownTimeout = 5 * time.Second
+)
+
+func Command(ctx context.Context, l *logrus.Logger, cfg *config.Config) {
+	if len(flag.Args()) < 2 {
+		l.Fatal("Missing subcommand. See: webhook <listen>")
+	}
+
+	subCommand := strings.ToLower(flag.Arg(1))
+
+	switch subCommand {
+	case "listen", "serve":
+		Listen(ctx, l, cfg)
+		return
+
+	default:
+		l.Fatalf("Unknown subcommand '%s'. See: webhook <listen>", subCommand)
+	}
+}
+
+// Listen runs a local webhook receiver which logs every verified webhook
+func Listen(ctx context.Context, l *logrus.Logger, cfg *config.Config) {
+	flags := flag.NewFlagSet("listen", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:8080", "The address to listen on.")
+	path := flags.String("path", "/", "The path to receive webhooks on.")
+	secret := flags.String("secret", cfg.Webhook.Secret, "The webhook secret, defaults to webhook.secret in your config.")
+	_ = flags.Parse(flag.Args()[2:])
+
+	if flags.NArg() != 0 || *secret == "" {
+		l.Fatal("usage: inti webhook listen [-addr localhost:8080] [-path /] [-secret <secret>]")
+	}
+
+	handler, err := webhook.New(webhook.Config{Secret: *secret, Logger: l})
+	if err != nil {
+		l.WithError(err).Fatal("could not create webhook handler")
+	}
+
+	handler.OnEvent(func(_ context.Context, event webhook.Event) error {
+		l.WithFields(logrus.Fields{
+			"id":   event.ID,
+			"type": event.Type,
+		}).Info(string(event.Data))
+		return nil
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle(*path, handler)
+
+	srv := http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	l.WithField("addr", *addr).WithField("path", *path).Info("listening for webhooks")
+
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		l.WithError(err).Fatal("webhook listener failed")
+	}
+}
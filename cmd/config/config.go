@@ -1,13 +1,15 @@
 package config
 
 import (
+	apiConfig "github.com/hazcod/go-intigriti/pkg/config"
 	"github.com/juju/fslock"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/oauth2"
 	"gopkg.in/yaml.v3"
 	"os"
+	"slices"
+	"sync"
 	"time"
 )
 
@@ -21,11 +23,36 @@ type Config struct {
 	} `yaml:"log"`
 
 	Auth struct {
+		// authorization_code (default), client_credentials or static_token
+		Mode         string `yaml:"mode"`
 		ClientID     string `yaml:"client_id"`
 		ClientSecret string `yaml:"client_secret"`
+		// the pre-issued access token of the static_token mode
+		Token string `yaml:"token"`
+		// how to complete the browser login: auto (default), browser or headless to paste the redirect URL
+		Login string `yaml:"login"`
+		// the API scopes requested for the token, read-only by default
+		// add core_platform:write to triage, message or pay out submissions
+		Scopes []string `yaml:"scopes"`
+
+		// the redirect URI registered for the integration, defaults to http://localhost:1337/
+		Callback struct {
+			Host string `yaml:"host"`
+			// tried in order until one is free
+			Ports []int  `yaml:"ports"`
+			Path  string `yaml:"path"`
+			// listen on a random port when none of the ports are free
+			EphemeralPort bool `yaml:"ephemeral_port" split_words:"true"`
+			// path to an HTML file shown after logging in
+			SuccessPage string `yaml:"success_page" split_words:"true"`
+		} `yaml:"callback"`
 	} `yaml:"auth"`
 
 	Cache TokenCache `yaml:"cache"`
+
+	Webhook struct {
+		Secret string `yaml:"secret"`
+	} `yaml:"webhook"`
 }
 
 type TokenCache struct {
@@ -33,6 +60,20 @@ type TokenCache struct {
 	AccessToken  string    `yaml:"access_token"`
 	ExpiryDate   time.Time `yaml:"expiry"`
 	Type         string    `yaml:"type"`
+	// the scopes the token was requested with, the token is not used once other scopes are configured
+	Scopes []string `yaml:"scopes"`
+}
+
+// the scopes requested when none are configured, which only allow reading
+var defaultAPIScopes = []string{"company_external_api", "core_platform:read"}
+
+// APIScopes returns the configured API scopes, or the read-only default scopes
+func (c *Config) APIScopes() []string {
+	if len(c.Auth.Scopes) == 0 {
+		return defaultAPIScopes
+	}
+
+	return c.Auth.Scopes
 }
 
 func Load(logger *logrus.Logger, path string) (*Config, error) {
@@ -58,6 +99,7 @@ func Load(logger *logrus.Logger, path string) (*Config, error) {
 	return &config, nil
 }
 
+// Save writes the whole configuration to the file, including the values loaded from the environment
 func (c *Config) Save(logger *logrus.Logger, path string) error {
 	b, err := yaml.Marshal(c)
 	if err != nil {
@@ -72,6 +114,23 @@ func (c *Config) Save(logger *logrus.Logger, path string) error {
 }
 
 func (c *Config) Validate() error {
+	authMode, err := apiConfig.ParseAuthMode(c.Auth.Mode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := apiConfig.ParseLoginMode(c.Auth.Login); err != nil {
+		return err
+	}
+
+	if authMode == apiConfig.AuthModeStaticToken {
+		if c.Auth.Token == "" {
+			return errors.New("no token provided for the static_token auth mode")
+		}
+
+		return nil
+	}
+
 	if c.Auth.ClientID == "" {
 		return errors.New("no clientid provided")
 	}
@@ -83,11 +142,7 @@ func (c *Config) Validate() error {
 	return nil
 }
 
-func (c *Config) CacheAuth(l *logrus.Logger, path string, token *oauth2.Token) error {
-	if !token.Valid() {
-		return errors.New("token is not valid")
-	}
-
+func (c *Config) cacheToken(l *logrus.Logger, path string, token *apiConfig.CachedToken) error {
 	lock := fslock.New(path)
 	if err := lock.TryLock(); err != nil {
 		return errors.Wrap(err, "could not lock config file")
@@ -101,12 +156,105 @@ func (c *Config) CacheAuth(l *logrus.Logger, path string, token *oauth2.Token) e
 
 	c.Cache.AccessToken = token.AccessToken
 	c.Cache.RefreshToken = token.RefreshToken
-	c.Cache.ExpiryDate = token.Expiry
-	c.Cache.Type = token.TokenType
+	c.Cache.ExpiryDate = token.ExpiryDate
+	c.Cache.Type = token.Type
+	c.Cache.Scopes = c.APIScopes()
 
-	if err := c.Save(l, path); err != nil {
+	if err := saveCache(path, c.Cache); err != nil {
 		return errors.Wrap(err, "failed to save config")
 	}
 
 	return nil
 }
+
+// saveCache replaces only the cache section of the configuration file and leaves the rest as is
+// so secrets passed as environment variables, such as INTI_AUTH_CLIENT_SECRET, never end up in the file
+func saveCache(path string, cache TokenCache) error {
+	b, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "could not read config")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return errors.Wrap(err, "could not parse config")
+	}
+
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return errors.New("config is not a YAML mapping")
+	}
+
+	var cacheNode yaml.Node
+	if err := cacheNode.Encode(cache); err != nil {
+		return errors.Wrap(err, "could not serialize token cache")
+	}
+
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "cache" {
+			root.Content[i+1] = &cacheNode
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "cache"}, &cacheNode)
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return errors.Wrap(err, "could not serialize config")
+	}
+
+	if err := os.WriteFile(path, out, 0600); err != nil {
+		return errors.Wrap(err, "could not write config")
+	}
+
+	return nil
+}
+
+// TokenStore returns a token store which keeps the token in the cache section of the configuration file
+// so tokens refreshed during a long run are cached too
+func (c *Config) TokenStore(l *logrus.Logger, path string) apiConfig.TokenStore {
+	return &configTokenStore{config: c, logger: l, path: path}
+}
+
+type configTokenStore struct {
+	mu     sync.Mutex
+	config *Config
+	logger *logrus.Logger
+	path   string
+}
+
+func (s *configTokenStore) Load() (*apiConfig.CachedToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config.Cache.AccessToken == "" {
+		return nil, nil
+	}
+
+	if !slices.Equal(s.config.Cache.Scopes, s.config.APIScopes()) {
+		s.logger.Info("the configured API scopes changed, logging in again")
+		return nil, nil
+	}
+
+	return &apiConfig.CachedToken{
+		RefreshToken: s.config.Cache.RefreshToken,
+		AccessToken:  s.config.Cache.AccessToken,
+		ExpiryDate:   s.config.Cache.ExpiryDate,
+		Type:         s.config.Cache.Type,
+	}, nil
+}
+
+func (s *configTokenStore) Save(token *apiConfig.CachedToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.config.cacheToken(s.logger, s.path, token)
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	apiConfig "github.com/hazcod/go-intigriti/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+func TestTokenStoreOnlyWritesCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inti.yml")
+	if err := os.WriteFile(path, []byte("# my settings\nauth:\n    client_id: my-client\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("INTI_AUTH_CLIENT_SECRET", "env-secret")
+	t.Setenv("INTI_WEBHOOK_SECRET", "env-webhook-secret")
+
+	cfg, err := Load(logrus.New(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := cfg.TokenStore(logrus.New(), path)
+	for _, accessToken := range []string{"first", "refreshed"} {
+		if err := store.Save(&apiConfig.CachedToken{AccessToken: accessToken, RefreshToken: "refresh", ExpiryDate: time.Now().Add(time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(b), "env-secret") || strings.Contains(string(b), "env-webhook-secret") {
+		t.Errorf("secrets from the environment were written to the file:\n%s", b)
+	}
+
+	if !strings.Contains(string(b), "# my settings") || !strings.Contains(string(b), "my-client") {
+		t.Errorf("the rest of the file was not kept:\n%s", b)
+	}
+
+	reloaded, err := Load(logrus.New(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token, err := reloaded.TokenStore(logrus.New(), path).Load(); err != nil || token == nil || token.AccessToken != "refreshed" {
+		t.Errorf("unexpected cached token %+v: %v", token, err)
+	}
+}
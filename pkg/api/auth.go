@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"fmt"
 	"github.com/hazcod/go-intigriti/pkg/config"
 	"net/http"
 	"os"
@@ -10,20 +9,20 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 const (
 	// timeout of every http request
 	httpTimeoutSec = 15
-	// the length of our Oauth2 state parameter
-	stateLengthLetters = 10
+	// the length of our Oauth2 state and nonce parameters, 43 characters hold 258 bits
+	stateLengthLetters = 43
 	// timeout of the local callback listener
 	callbackTimeoutSec = 120
 
-	// local callback url listener
-	localCallbackPort = 1337
-	localCallbackHost = "localhost"
-	localCallbackURI  = "/"
+	// default local callback url listener
+	defaultCallbackPort = 1337
+	defaultCallbackHost = "localhost"
 
 	// default production API endpoints
 	defaultApiTokenURL = "https://login.intigriti.com/connect/token"
@@ -64,7 +63,7 @@ func (e *Endpoint) getOauth2Config(apiScopes []string) oauth2.Config {
 			TokenURL: tokenURL,
 			AuthURL:  authzURL,
 		},
-		RedirectURL: fmt.Sprintf("http://%s:%d%s", localCallbackHost, localCallbackPort, localCallbackURI),
+		RedirectURL: e.callbackURL(e.callback.Ports[0]),
 		Scopes:      apiScopes,
 	}
 
@@ -73,72 +72,141 @@ func (e *Endpoint) getOauth2Config(apiScopes []string) oauth2.Config {
 	return oauthConfig
 }
 
+// retrieve the oauth2 configuration of the non-interactive client credentials flow
+func (e *Endpoint) getClientCredentialsConfig() clientcredentials.Config {
+	return clientcredentials.Config{
+		ClientID:     e.clientID,
+		ClientSecret: e.clientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       e.apiScopes,
+	}
+}
+
+// tokenSource returns the source which renews the current token for the configured auth mode
+// renewed tokens are saved to the token store, if any
+func (e *Endpoint) tokenSource(ctx context.Context, current *oauth2.Token) oauth2.TokenSource {
+	var source oauth2.TokenSource
+
+	switch e.authMode {
+	case config.AuthModeClientCredentials:
+		conf := e.getClientCredentialsConfig()
+		source = conf.TokenSource(ctx)
+	case config.AuthModeStaticToken:
+		return oauth2.StaticTokenSource(current)
+	default:
+		conf := e.getOauth2Config(e.apiScopes)
+		source = conf.TokenSource(ctx, current)
+	}
+
+	if e.tokenStore == nil {
+		return source
+	}
+
+	return persistingTokenSource{Source: source, Store: e.tokenStore, Logger: e.logger}
+}
+
 // GetToken fetch the latest (valid) oauth2 access and refresh token
 func (e *Endpoint) GetToken() (*oauth2.Token, error) {
+	return e.GetTokenWithContext(context.Background())
+}
+
+// GetTokenWithContext fetch the latest (valid) oauth2 access and refresh token
+// the context is used when the token has to be refreshed
+func (e *Endpoint) GetTokenWithContext(ctx context.Context) (*oauth2.Token, error) {
+	e.token.mu.Lock()
+	defer e.token.mu.Unlock()
+
 	// don't do anything when the token is ok
-	if e.oauthToken != nil && e.oauthToken.Valid() {
-		return e.oauthToken, nil
+	if e.token.token != nil && e.token.token.Valid() {
+		return e.token.token, nil
 	}
 
-	// get out oauth2 config to use
-	conf := e.getOauth2Config(e.apiScopes)
-
 	// get valid refresh and access tokens
-	tokenSrc := conf.TokenSource(context.Background(), e.oauthToken)
-	token, err := tokenSrc.Token()
+	token, err := e.tokenSource(getOauth2Context(ctx), e.token.token).Token()
 	if err != nil {
-		return nil, errors.Wrap(err, "could not retrieve refresh token")
+		return nil, errors.Wrap(fromRetrieveError(err), "could not retrieve refresh token")
 	}
 
+	e.token.token = token
+
 	return token, nil
 }
 
-// return the http client which automatically injects the right authentication credentials
-func (e *Endpoint) getClient(tc *config.CachedToken, auth *config.InteractiveAuthenticator) (*http.Client, error) {
-	ctx := context.Background()
+// getOauth2Context returns a context which makes the oauth2 library use our own http client
+func getOauth2Context(ctx context.Context) context.Context {
+	httpClient := &http.Client{Timeout: httpTimeoutSec * time.Second}
+	return context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+}
 
+// return the http client which automatically injects the right authentication credentials
+func (e *Endpoint) getClient(ctx context.Context, tc *config.CachedToken, auth *config.InteractiveAuthenticator) (*http.Client, error) {
 	conf := e.getOauth2Config(e.apiScopes)
 
-	httpClient := &http.Client{Timeout: httpTimeoutSec * time.Second}
-	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	ctx = getOauth2Context(ctx)
 
-	e.oauthToken = &oauth2.Token{}
+	token := &oauth2.Token{}
 
 	if tc == nil {
 		tc = &config.CachedToken{}
 	}
 
-	if tc.AccessToken != "" {
+	if e.authMode == config.AuthModeStaticToken {
+		e.logger.Debug("using static access token")
+		token = &oauth2.Token{AccessToken: e.staticToken, TokenType: "Bearer"}
+	} else if tc.AccessToken != "" {
 		e.logger.Debug("using cached access token")
-		e.oauthToken = &oauth2.Token{
-			AccessToken:  tc.AccessToken,
-			RefreshToken: tc.RefreshToken,
-			Expiry:       tc.ExpiryDate,
-			TokenType:    tc.Type,
+		token = fromCachedToken(tc)
+	}
+
+	// an expired token is refreshed before asking the user to log in again
+	if !token.Valid() && token.RefreshToken != "" && e.authMode.Interactive() {
+		refreshed, err := e.tokenSource(ctx, token).Token()
+		if err != nil {
+			e.logger.WithError(fromRetrieveError(err)).Debug("could not refresh cached token")
+		} else {
+			e.logger.Debug("refreshed cached access token")
+			token = refreshed
 		}
 	}
 
-	if e.oauthToken.Valid() {
+	if token.Valid() {
 		e.logger.Debug("cached access token is valid, skipping authentication")
+	} else if e.authMode == config.AuthModeClientCredentials {
+		e.logger.Debug("access token is invalid or expired, requesting new token with client credentials")
+
+		var err error
+		if token, err = e.tokenSource(ctx, nil).Token(); err != nil {
+			return nil, errors.Wrap(fromRetrieveError(err), "could not retrieve token with client credentials")
+		}
 	} else {
 		e.logger.Debug("access token is invalid or expired, authenticating for new token")
 
-		authzCode, err := e.authenticate(ctx, &conf, auth, e.oauthToken.AccessToken)
+		newToken, err := e.authenticate(ctx, &conf, auth, token)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to authenticate")
 		}
 
-		if authzCode != "" {
-			e.logger.WithField("code", authzCode).Debug("exchanging code")
-			e.oauthToken, err = conf.Exchange(ctx, authzCode)
-			if err != nil {
-				return nil, errors.Wrap(err, "could not exchange code")
-			}
+		if newToken != token {
+			saveToken(e.logger, e.tokenStore, newToken)
 		}
+
+		token = newToken
 	}
 
-	// Ensure our HTTP client uses the OAuth2 credentials
-	authHttpClient := conf.Client(ctx, e.oauthToken)
+	e.token.mu.Lock()
+	e.token.token = token
+	e.token.mu.Unlock()
+
+	// Ensure our HTTP client uses the OAuth2 credentials, refreshed with the context of each request
+	authHttpClient := &http.Client{
+		Transport: tokenRoundTripper{Proxied: http.DefaultTransport, Token: e.GetTokenWithContext},
+	}
+
+	// Keep within our request budget, also when retrying
+	authHttpClient.Transport = rateLimitedRoundTripper{Proxied: authHttpClient.Transport, Logger: e.logger, Limiter: e.limiter}
+
+	// Retry transient failures, every attempt being authenticated separately
+	authHttpClient.Transport = RetryRoundTripper{Proxied: authHttpClient.Transport, Logger: e.logger, Config: e.retryConfig}
 
 	// Inject a logging middleware into the HTTP client
 	authHttpClient.Transport = TaggedRoundTripper{Proxied: authHttpClient.Transport, Logger: e.logger}
@@ -148,25 +216,27 @@ func (e *Endpoint) getClient(tc *config.CachedToken, auth *config.InteractiveAut
 }
 
 // authenticate authenticates with the Intigriti API using either an access token or interactive OAuth.
-func (e *Endpoint) authenticate(ctx context.Context, oauth2Config *oauth2.Config, auth *config.InteractiveAuthenticator, accessToken string) (string, error) {
-	if accessToken != "" {
+// the interactive flow uses PKCE (S256) so an intercepted authorization code cannot be exchanged by others
+func (e *Endpoint) authenticate(ctx context.Context, oauth2Config *oauth2.Config, auth *config.InteractiveAuthenticator, token *oauth2.Token) (*oauth2.Token, error) {
+	if token.AccessToken != "" {
 		e.logger.Info("validating provided access token")
 
-		client := oauth2Config.Client(ctx, &oauth2.Token{AccessToken: accessToken})
+		client := oauth2Config.Client(ctx, &oauth2.Token{AccessToken: token.AccessToken})
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.intigriti.com/v1/userinfo", nil) // Example endpoint
 		if err != nil {
 			e.logger.WithError(err).Error("failed to create validation request")
-			return "", err
+			return nil, err
 		}
 
 		resp, err := client.Do(req)
-		defer resp.Body.Close()
 		if err != nil {
 			e.logger.WithError(err).Warn("access token validation failed, proceeding to interactive authentication")
 		} else {
+			defer resp.Body.Close()
+
 			if resp.StatusCode == http.StatusOK {
 				e.logger.Debug("access token is valid")
-				return accessToken, nil
+				return token, nil
 			} else {
 				e.logger.WithField("status", resp.StatusCode).Warn("access token invalid, proceeding to interactive authentication")
 			}
@@ -175,20 +245,50 @@ func (e *Endpoint) authenticate(ctx context.Context, oauth2Config *oauth2.Config
 
 	// No valid access token provided, start interactive authentication flow
 	e.logger.Info("starting interactive authentication flow")
-	state := randomString(stateLengthLetters)
-	resultChan := make(chan callbackResult, 1)
+
+	state, err := randomString(stateLengthLetters)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate state")
+	}
+
+	nonce, err := randomString(stateLengthLetters)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate nonce")
+	}
+
+	verifier := oauth2.GenerateVerifier()
+	resultChan := make(chan callbackResult, 2)
 
 	ctx, cancel := context.WithTimeout(ctx, time.Second*callbackTimeoutSec)
-	defer func() { go func() { cancel(); resultChan <- callbackResult{} }() }()
+	defer cancel()
+
+	// the redirect URI has to point at the port we could listen on, also when exchanging the code
+	conf := *oauth2Config
+	oauth2Config = &conf
 
-	go e.listenForCallback(localCallbackURI, localCallbackHost, localCallbackPort, state, resultChan)
+	if !e.headless {
+		listener, port, err := e.bindCallback()
+		if err != nil {
+			return nil, err
+		}
+
+		oauth2Config.RedirectURL = e.callbackURL(port)
+		go e.listenForCallback(ctx, listener, state, resultChan)
+	}
 
 	// Generate the authentication URL
-	url := oauth2Config.AuthCodeURL(state, oauth2.AccessTypeOffline)
+	url := oauth2Config.AuthCodeURL(state, oauth2.AccessTypeOffline,
+		oauth2.S256ChallengeOption(verifier), oauth2.SetAuthURLParam("nonce", nonce))
 
 	// Log the URL only if no valid access token was provided
 	e.logger.Warnf("Please authenticate: %s", url)
 
+	// The browser cannot reach our listener, so the user copies the address of the page it was redirected to
+	if e.headless {
+		e.logger.Warn("After logging in, your browser is sent to a page which may fail to load. Paste its full URL here:")
+		go e.readPastedCallback(ctx, e.loginInput, state, resultChan)
+	}
+
 	// Attempt to open the system browser for authentication
 	if auth != nil {
 		e.logger.Info("opening system browser to authenticate")
@@ -203,20 +303,25 @@ func (e *Endpoint) authenticate(ctx context.Context, oauth2Config *oauth2.Config
 	var chanResult callbackResult
 	select {
 	case <-ctx.Done():
-		resultChan <- callbackResult{Error: errors.New("timeout")}
+		chanResult.Error = errors.Wrap(ctx.Err(), "no login callback received")
 	case chanResult = <-resultChan:
 	}
 
-	e.logger.WithField("result", chanResult).Debug("received callback result")
-
 	if chanResult.Error != nil {
-		return "", chanResult.Error
+		return nil, chanResult.Error
+	}
+
+	e.logger.Debug("exchanging code")
+
+	newToken, err := oauth2Config.Exchange(ctx, chanResult.Code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, errors.Wrap(fromRetrieveError(err), "could not exchange code")
 	}
 
-	if chanResult.Code == "" {
-		return "", errors.New("got empty code")
+	if err := validateNonce(newToken, nonce); err != nil {
+		return nil, err
 	}
 
-	e.logger.WithField("code", chanResult.Code).Debug("successfully retrieved new code")
-	return chanResult.Code, nil
+	e.logger.Debug("successfully retrieved new token")
+	return newToken, nil
 }
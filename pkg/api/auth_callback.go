@@ -2,8 +2,19 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"github.com/hazcod/go-intigriti/pkg/config"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"html"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,13 +25,78 @@ const (
 		<script>window.close();</script>
 	</html>
 	`
+	htmlAuthError = `
+	<html>
+		<body><h1>Authentication failed</h1><p>%s</p></body>
+	</html>
+	`
 )
 
+// errInvalidState is returned for a callback which was not the result of our own login request
+var errInvalidState = errors.New("invalid state parameter, the login may have been forged")
+
 type callbackResult struct {
 	Error error
 	Code  string
 }
 
+// parseCallback returns the authorization code from the query of the redirect after authenticating
+// the state parameter is compared first to prevent csrf, as also error redirects carry it
+func parseCallback(query url.Values, state string) (string, error) {
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+		return "", errInvalidState
+	}
+
+	if errorCode := query.Get("error"); errorCode != "" {
+		return "", &AuthorizationError{
+			Code:        errorCode,
+			Description: query.Get("error_description"),
+			URI:         query.Get("error_uri"),
+		}
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		return "", errors.New("callback did not contain an authorization code")
+	}
+
+	return code, nil
+}
+
+// validateNonce compares the nonce of the ID token with the one of our login request
+// an ID token is only returned by the token endpoint when the openid scope was requested
+// its signature is not verified as it was received directly from the token endpoint over TLS
+func validateNonce(token *oauth2.Token, nonce string) error {
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		return nil
+	}
+
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return errors.New("invalid ID token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return errors.Wrap(err, "could not decode ID token")
+	}
+
+	var claims struct {
+		Nonce string `json:"nonce"`
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return errors.Wrap(err, "could not parse ID token")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return errors.New("ID token nonce does not match the login request")
+	}
+
+	return nil
+}
+
 // this is the local http listener which will be called after successfully authenticating to Intigriti
 // here we will compare the state parameter to prevent csrf and extract the authorization code
 func (e *Endpoint) getLocalHandler(uri, state string, resultChan chan callbackResult, doneChan chan struct{}) http.Handler {
@@ -31,46 +107,117 @@ func (e *Endpoint) getLocalHandler(uri, state string, resultChan chan callbackRe
 			return
 		}
 
-		if r.URL.Query().Get("state") != state {
-			e.logger.WithField("required_state", state).WithField("given_state", r.URL.Query().Get("state")).
-				Warn("invalid state provided")
+		code, err := parseCallback(r.URL.Query(), state)
+		if errors.Is(err, errInvalidState) {
+			// keep waiting for the genuine callback
+			e.logger.WithField("given_state", r.URL.Query().Get("state")).Warn("invalid state provided")
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
 		w.Header().Set("content-type", "text/html; charset=UTF-8")
-		_, _ = w.Write([]byte(htmlAutoClose))
+
+		if err != nil {
+			e.logger.WithError(err).Warn("callback did not return an authorization code")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, htmlAuthError, html.EscapeString(err.Error()))
+		} else {
+			_, _ = w.Write([]byte(e.callback.SuccessPage))
+			e.logger.Debug("callback successfully got code")
+		}
 
 		resultChan <- callbackResult{
-			Code:  r.URL.Query().Get("code"),
-			Error: nil,
+			Code:  code,
+			Error: err,
 		}
 
 		doneChan <- struct{}{}
-
-		e.logger.WithField("code", r.URL.Query().Get("code")).Debug("callback successfully got code")
 	})
 }
 
-// helper function that creates the callback listener and waits until a response is received or timeout expires
-func (e *Endpoint) listenForCallback(uri, localHost string, localPort uint, state string, resultChan chan callbackResult) {
-	e.logger.WithField("port", localPort).Debug("listening for callback for new authorization code")
+// newCallbackConfig applies the defaults to the callback configuration
+func newCallbackConfig(cfg config.CallbackConfig) config.CallbackConfig {
+	if cfg.Host == "" {
+		cfg.Host = defaultCallbackHost
+	}
+
+	if len(cfg.Ports) == 0 {
+		cfg.Ports = []int{defaultCallbackPort}
+	}
+
+	if !strings.HasPrefix(cfg.Path, "/") {
+		cfg.Path = "/" + cfg.Path
+	}
+
+	if cfg.SuccessPage == "" {
+		cfg.SuccessPage = htmlAutoClose
+	}
+
+	return cfg
+}
+
+// callbackURL returns the redirect URI for the callback listener on the given port
+func (e *Endpoint) callbackURL(port int) string {
+	return "http://" + net.JoinHostPort(e.callback.Host, strconv.Itoa(port)) + e.callback.Path
+}
+
+// bindCallback binds the local callback listener to the first free port of the configured ones
+// and to a random free port if allowed when none are
+func (e *Endpoint) bindCallback() (net.Listener, int, error) {
+	var bindErrors []string
+
+	for _, port := range e.callback.Ports {
+		addr := net.JoinHostPort(e.callback.Host, strconv.Itoa(port))
+
+		listener, err := net.Listen("tcp", addr)
+		if err == nil {
+			return listener, port, nil
+		}
+
+		e.logger.WithError(err).WithField("address", addr).Warn("could not listen for the login callback")
+		bindErrors = append(bindErrors, err.Error())
+	}
+
+	if e.callback.AllowEphemeralPort {
+		listener, err := net.Listen("tcp", net.JoinHostPort(e.callback.Host, "0"))
+		if err == nil {
+			port := listener.Addr().(*net.TCPAddr).Port
+			e.logger.WithField("port", port).Warn("listening for the login callback on a random port, which must be accepted as redirect URI")
+			return listener, port, nil
+		}
+
+		bindErrors = append(bindErrors, err.Error())
+	}
+
+	return nil, 0, errors.Errorf("could not listen for the login callback on %s port(s) %v (%s), "+
+		"free a port, configure other registered callback ports or use the headless login",
+		e.callback.Host, e.callback.Ports, strings.Join(bindErrors, "; "))
+}
+
+// helper function that serves the callback listener until a response is received or the context expires
+func (e *Endpoint) listenForCallback(ctx context.Context, listener net.Listener, state string, resultChan chan callbackResult) {
+	e.logger.WithField("address", listener.Addr().String()).Debug("listening for callback for new authorization code")
 
 	doneChan := make(chan struct{}, 2)
 
-	srv := http.Server{}
-	srv.Addr = fmt.Sprintf("%s:%d", localHost, localPort)
-	srv.Handler = e.getLocalHandler(uri, state, resultChan, doneChan)
+	srv := http.Server{Handler: e.getLocalHandler(e.callback.Path, state, resultChan, doneChan)}
 
 	go func() {
-		<-doneChan
-		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
-		_ = srv.Shutdown(ctx)
+		select {
+		case <-doneChan:
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_ = srv.Shutdown(shutdownCtx)
 		e.logger.Debug("shut down local callback listener")
-		cancel() // just to fix govet
 	}()
 
-	err := srv.ListenAndServe()
-	resultChan <- callbackResult{Error: err}
+	if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		resultChan <- callbackResult{Error: errors.Wrap(err, "login callback listener failed")}
+	}
+
 	e.logger.Debug("returning from listenForCallback")
 }
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hazcod/go-intigriti/pkg/config"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+func TestParseCallback(t *testing.T) {
+	const state = "expected-state"
+
+	tests := []struct {
+		name  string
+		query string
+		code  string
+		check func(error) bool
+	}{
+		{"code", "state=expected-state&code=abc", "abc", func(err error) bool { return err == nil }},
+		{"forged state", "state=other&code=abc", "", func(err error) bool { return errors.Is(err, errInvalidState) }},
+		{"missing state", "code=abc", "", func(err error) bool { return errors.Is(err, errInvalidState) }},
+		{"denied", "state=expected-state&error=access_denied&error_description=User+cancelled", "", func(err error) bool {
+			var authErr *AuthorizationError
+			return errors.As(err, &authErr) && authErr.Code == "access_denied" && authErr.Description == "User cancelled"
+		}},
+		{"missing code", "state=expected-state", "", func(err error) bool { return err != nil && !errors.Is(err, errInvalidState) }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			code, err := parseCallback(query, state)
+			if code != test.code || !test.check(err) {
+				t.Errorf("unexpected code '%s' and error %v", code, err)
+			}
+		})
+	}
+}
+
+func TestParsePastedCallback(t *testing.T) {
+	const state = "expected-state"
+
+	tests := map[string]string{
+		"http://localhost:1337/?code=abc&state=expected-state": "abc",
+		"?code=abc&state=expected-state":                       "abc",
+		"code=abc&state=expected-state":                        "abc",
+	}
+
+	for pasted, expected := range tests {
+		if code, err := parsePastedCallback(pasted, state); err != nil || code != expected {
+			t.Errorf("unexpected code '%s' and error %v for '%s'", code, err, pasted)
+		}
+	}
+
+	if _, err := parsePastedCallback("http://localhost:1337/?code=abc&state=other", state); !errors.Is(err, errInvalidState) {
+		t.Errorf("expected forged state to be rejected, got %v", err)
+	}
+
+	for _, pasted := range []string{"abc", "http://localhost:1337/", "http://localhost:1337/?code=abc"} {
+		if code, err := parsePastedCallback(pasted, state); err == nil {
+			t.Errorf("expected '%s' without state to be rejected, got code '%s'", pasted, code)
+		}
+	}
+}
+
+func TestReadLine(t *testing.T) {
+	input := strings.NewReader("\n  http://localhost:1337/?code=abc&state=s  \nmessage body\n")
+
+	line, err := readLine(context.Background(), input)
+	if err != nil || line != "http://localhost:1337/?code=abc&state=s" {
+		t.Fatalf("unexpected line '%s': %v", line, err)
+	}
+
+	if rest, _ := io.ReadAll(input); string(rest) != "message body\n" {
+		t.Errorf("input after the line was consumed, left '%s'", rest)
+	}
+
+	if _, err := readLine(context.Background(), strings.NewReader(" \n")); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected unexpected EOF, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	input = strings.NewReader("later input\n")
+	if _, err := readLine(ctx, input); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation, got %v", err)
+	}
+
+	if input.Len() != len("later input\n") {
+		t.Error("input was read after the login was cancelled")
+	}
+}
+
+func TestValidateNonce(t *testing.T) {
+	idToken := "e30." + base64.RawURLEncoding.EncodeToString([]byte(`{"nonce":"n0nce"}`)) + ".c2ln"
+	token := (&oauth2.Token{AccessToken: "a"}).WithExtra(map[string]interface{}{"id_token": idToken})
+
+	if err := validateNonce(token, "n0nce"); err != nil {
+		t.Errorf("expected nonce to be valid: %v", err)
+	}
+
+	if err := validateNonce(token, "other"); err == nil {
+		t.Error("expected nonce mismatch")
+	}
+
+	if err := validateNonce(&oauth2.Token{AccessToken: "a"}, "n0nce"); err != nil {
+		t.Errorf("expected a token without ID token to be accepted: %v", err)
+	}
+}
+
+func TestBindCallback(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer taken.Close()
+
+	takenPort := taken.Addr().(*net.TCPAddr).Port
+
+	e := &Endpoint{logger: logrus.New(), callback: newCallbackConfig(config.CallbackConfig{Host: "127.0.0.1", Ports: []int{takenPort}})}
+
+	if _, _, err := e.bindCallback(); err == nil || !strings.Contains(err.Error(), strconv.Itoa(takenPort)) {
+		t.Errorf("expected bind failure mentioning port %d, got %v", takenPort, err)
+	}
+
+	e.callback.AllowEphemeralPort = true
+
+	listener, port, err := e.bindCallback()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	if port == takenPort || e.callbackURL(port) != "http://127.0.0.1:"+strconv.Itoa(port)+"/" {
+		t.Errorf("unexpected callback URL %s", e.callbackURL(port))
+	}
+}
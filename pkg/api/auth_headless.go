@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// hasDisplay returns whether a browser on this machine is likely to reach our local callback listener
+// SSH sessions and containers without a display server need the headless login instead
+func hasDisplay() bool {
+	x11 := os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		// only a forwarded display opens the browser on the remote machine itself
+		return x11
+	}
+
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		return true
+	default:
+		return x11
+	}
+}
+
+// parsePastedCallback returns the authorization code from the redirect URL or its query pasted by the user
+// the state is checked just like for the local callback, so a bare code without its state is refused
+func parsePastedCallback(pasted, state string) (string, error) {
+	if !strings.Contains(pasted, "=") {
+		return "", errors.New("paste the full URL the browser was redirected to, including its code and state")
+	}
+
+	rawQuery := strings.TrimPrefix(pasted, "?")
+	if redirect, err := url.Parse(pasted); err == nil && redirect.RawQuery != "" {
+		rawQuery = redirect.RawQuery
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", errors.Wrap(err, "could not parse the pasted redirect URL")
+	}
+
+	return parseCallback(query, state)
+}
+
+// readPastedCallback waits for the user to paste the URL the browser was redirected to after logging in
+// only that single line is read, anything after it is left for later prompts such as a confirmation or a piped message
+func (e *Endpoint) readPastedCallback(ctx context.Context, input io.Reader, state string, resultChan chan callbackResult) {
+	pasted, err := readLine(ctx, input)
+	if err != nil {
+		resultChan <- callbackResult{Error: errors.Wrap(err, "could not read the pasted redirect URL")}
+		return
+	}
+
+	code, err := parsePastedCallback(pasted, state)
+	resultChan <- callbackResult{Code: code, Error: err}
+}
+
+// readLine returns the first non-empty line of the input, trimmed
+// the input is read one byte at a time as buffering would consume input beyond the line,
+// it stops before reading on once the context is done, although a read already waiting for input cannot be interrupted
+func readLine(ctx context.Context, input io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		n, err := input.Read(b)
+		if n > 0 && b[0] != '\n' {
+			line = append(line, b[0])
+			continue
+		}
+
+		if n > 0 || (errors.Is(err, io.EOF) && len(line) > 0) {
+			if text := strings.TrimSpace(string(line)); text != "" {
+				return text, nil
+			}
+
+			line = line[:0]
+			continue
+		}
+
+		if errors.Is(err, io.EOF) {
+			return "", io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+}
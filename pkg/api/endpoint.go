@@ -1,12 +1,16 @@
 package api
 
 import (
+	"context"
 	"github.com/hazcod/go-intigriti/pkg/config"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
+	"io"
 	"net/http"
+	"os"
 	"strings"
+	"sync"
 )
 
 const (
@@ -21,25 +25,69 @@ type Endpoint struct {
 	clientTag    string
 
 	client     *http.Client
-	oauthToken *oauth2.Token
+	token      *tokenHolder
+	tokenStore config.TokenStore
 
 	apiScopes []string
+
+	authMode    config.AuthMode
+	staticToken string
+
+	// headless logins read the pasted redirect URL from the login input instead of listening for the callback
+	headless   bool
+	loginInput io.Reader
+	callback   config.CallbackConfig
+
+	retryConfig config.RetryConfig
+	limiter     *rateLimiter
+}
+
+// tokenHolder keeps the current oauth2 token, shared between copies of the Endpoint and its http transport
+type tokenHolder struct {
+	mu    sync.Mutex
+	token *oauth2.Token
 }
 
 // New creates an Intigriti endpoint object to use
 // this is the main object to interact with the SDK
 func New(cfg config.Config) (Endpoint, error) {
+	return NewWithContext(context.Background(), cfg)
+}
+
+// NewWithContext creates an Intigriti endpoint object to use
+// the context is used for the initial authentication and token retrieval
+func NewWithContext(ctx context.Context, cfg config.Config) (Endpoint, error) {
 	e := Endpoint{
 		clientID:     cfg.Credentials.ClientID,
 		clientSecret: cfg.Credentials.ClientSecret,
 		clientTag:    clientTag,
 		apiScopes:    cfg.APIScopes,
+		token:        &tokenHolder{},
+		callback:     newCallbackConfig(cfg.Callback),
+		retryConfig:  cfg.Retry,
+		limiter:      newRateLimiter(cfg.RateLimit),
 	}
 
 	if len(e.apiScopes) == 0 {
 		e.apiScopes = strings.Split(apiAllScopes, " ")
 	}
 
+	authMode, err := config.ParseAuthMode(string(cfg.AuthMode))
+	if err != nil {
+		return e, errors.Wrap(err, "invalid auth mode")
+	}
+
+	e.authMode = authMode
+	e.staticToken = strings.TrimSpace(cfg.StaticToken)
+
+	// fail fast as the non-interactive modes cannot fall back to asking the user
+	switch {
+	case authMode == config.AuthModeClientCredentials && (e.clientID == "" || e.clientSecret == ""):
+		return e, errors.New("the client credentials auth mode requires a client id and client secret")
+	case authMode == config.AuthModeStaticToken && e.staticToken == "":
+		return e, errors.New("the static token auth mode requires a static token")
+	}
+
 	// initialize the logger to use
 	if cfg.Logger == nil {
 		e.logger = logrus.New()
@@ -47,13 +95,45 @@ func New(cfg config.Config) (Endpoint, error) {
 		e.logger = cfg.Logger
 	}
 
+	loginMode, err := config.ParseLoginMode(string(cfg.LoginMode))
+	if err != nil {
+		return e, errors.Wrap(err, "invalid login mode")
+	}
+
+	switch loginMode {
+	case config.LoginModeHeadless:
+		e.headless = true
+	case config.LoginModeAuto:
+		e.headless = !hasDisplay()
+	}
+
+	e.loginInput = cfg.LoginInput
+	if e.loginInput == nil {
+		e.loginInput = os.Stdin
+	}
+
 	// prepare our oauth2-ed http client
 	authenticator := &cfg.Authenticator
-	if !cfg.OpenBrowser {
+	if !cfg.OpenBrowser || !authMode.Interactive() || e.headless {
 		authenticator = nil
 	}
 
-	httpClient, err := e.getClient(cfg.TokenCache, authenticator)
+	// a stored token takes precedence over the one passed in
+	tokenCache := cfg.TokenCache
+	if cfg.TokenStore != nil && authMode != config.AuthModeStaticToken {
+		e.tokenStore = cfg.TokenStore
+
+		stored, err := cfg.TokenStore.Load()
+		if err != nil {
+			return e, errors.Wrap(err, "could not load stored token")
+		}
+
+		if stored != nil {
+			tokenCache = stored
+		}
+	}
+
+	httpClient, err := e.getClient(ctx, tokenCache, authenticator)
 	if err != nil {
 		return e, errors.Wrap(err, "could not init client")
 	}
@@ -61,7 +141,7 @@ func New(cfg config.Config) (Endpoint, error) {
 	e.client = httpClient
 
 	// ensure our current token is fetched or renewed if expired
-	if _, err = e.GetToken(); err != nil {
+	if _, err = e.GetTokenWithContext(ctx); err != nil {
 		return e, errors.Wrap(err, "could not prepare token")
 	}
 
@@ -70,9 +150,16 @@ func New(cfg config.Config) (Endpoint, error) {
 
 // IsAuthenticated returns whether the current SDK instance has successfully authenticated
 func (e *Endpoint) IsAuthenticated() bool {
-	if e.oauthToken == nil {
+	if e.token == nil {
+		return false
+	}
+
+	e.token.mu.Lock()
+	defer e.token.mu.Unlock()
+
+	if e.token.token == nil {
 		return false
 	}
 
-	return e.oauthToken.Valid()
+	return e.token.token.Valid()
 }
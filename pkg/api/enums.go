@@ -0,0 +1,287 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hazcod/go-intigriti/pkg/cvss"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// EnumValue is an {id, value} enumeration as returned by the API
+// the typed identifier is meant for comparisons, the value is the display name the API returned for it
+type EnumValue[T ~int] struct {
+	ID    T
+	Value string
+}
+
+// String returns the display name returned by the API, or the name of the identifier when there was none
+func (v EnumValue[T]) String() string {
+	if v.Value != "" {
+		return v.Value
+	}
+
+	return fmt.Sprint(v.ID)
+}
+
+func (v EnumValue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enumValue{ID: int(v.ID), Value: v.String()})
+}
+
+// UnmarshalJSON decodes an {id, value} object, a bare identifier or null
+func (v *EnumValue[T]) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	*v = EnumValue[T]{}
+
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	if len(b) > 0 && b[0] == '{' {
+		var value enumValue
+		if err := json.Unmarshal(b, &value); err != nil {
+			return err
+		}
+
+		*v = EnumValue[T]{ID: T(value.ID), Value: value.Value}
+		return nil
+	}
+
+	var id int
+	if err := json.Unmarshal(b, &id); err != nil {
+		return err
+	}
+
+	v.ID = T(id)
+	return nil
+}
+
+type enumValue struct {
+	ID    int    `json:"id"`
+	Value string `json:"value"`
+}
+
+func enumString[T ~int](names map[T]string, typeName string, value T) string {
+	if name, found := names[value]; found {
+		return name
+	}
+
+	return fmt.Sprintf("%s(%d)", typeName, int(value))
+}
+
+// parseEnum returns the value for its display name, ignoring case and treating dashes and underscores as spaces
+func parseEnum[T ~int](names map[T]string, typeName, name string) (T, error) {
+	normalize := func(s string) string {
+		return strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(strings.TrimSpace(s)))
+	}
+
+	for value, valueName := range names {
+		if normalize(valueName) == normalize(name) {
+			return value, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown %s: %s", typeName, name)
+}
+
+type SubmissionStatus int
+
+const (
+	SubmissionStatusDraft    SubmissionStatus = 1
+	SubmissionStatusTriage   SubmissionStatus = 2
+	SubmissionStatusAccepted SubmissionStatus = 3
+	SubmissionStatusClosed   SubmissionStatus = 4
+	SubmissionStatusArchived SubmissionStatus = 5
+)
+
+var submissionStatusNames = map[SubmissionStatus]string{
+	SubmissionStatusDraft:    "Draft",
+	SubmissionStatusTriage:   "Triage",
+	SubmissionStatusAccepted: "Accepted",
+	SubmissionStatusClosed:   "Closed",
+	SubmissionStatusArchived: "Archived",
+}
+
+// ParseSubmissionStatus returns the submission status for its name, such as 'triage'
+func ParseSubmissionStatus(name string) (SubmissionStatus, error) {
+	return parseEnum(submissionStatusNames, "submission status", name)
+}
+
+func (s SubmissionStatus) String() string {
+	return enumString(submissionStatusNames, "SubmissionStatus", s)
+}
+
+type CloseReason int
+
+const (
+	CloseReasonResolved        CloseReason = 1
+	CloseReasonDuplicate       CloseReason = 2
+	CloseReasonAcceptedRisk    CloseReason = 3
+	CloseReasonInformative     CloseReason = 4
+	CloseReasonOutOfScope      CloseReason = 5
+	CloseReasonSpam            CloseReason = 6
+	CloseReasonNotReproducible CloseReason = 7
+)
+
+var closeReasonNames = map[CloseReason]string{
+	CloseReasonResolved:        "Resolved",
+	CloseReasonDuplicate:       "Duplicate",
+	CloseReasonAcceptedRisk:    "Accepted risk",
+	CloseReasonInformative:     "Informative",
+	CloseReasonOutOfScope:      "Out of scope",
+	CloseReasonSpam:            "Spam",
+	CloseReasonNotReproducible: "Not reproducible",
+}
+
+// ParseCloseReason returns the close reason for its name, such as 'duplicate' or 'out-of-scope'
+func ParseCloseReason(name string) (CloseReason, error) {
+	return parseEnum(closeReasonNames, "close reason", name)
+}
+
+func (r CloseReason) String() string {
+	return enumString(closeReasonNames, "CloseReason", r)
+}
+
+// Severity is ordered from undecided to exceptional, compare it with Compare or AtLeast
+type Severity int
+
+const (
+	SeverityUndecided   Severity = 1
+	SeverityLow         Severity = 2
+	SeverityMedium      Severity = 3
+	SeverityHigh        Severity = 4
+	SeverityCritical    Severity = 5
+	SeverityExceptional Severity = 6
+)
+
+var severityNames = map[Severity]string{
+	SeverityUndecided:   "Undecided",
+	SeverityLow:         "Low",
+	SeverityMedium:      "Medium",
+	SeverityHigh:        "High",
+	SeverityCritical:    "Critical",
+	SeverityExceptional: "Exceptional",
+}
+
+// ParseSeverity returns the severity for its name, such as 'low' or 'critical'
+func ParseSeverity(name string) (Severity, error) {
+	return parseEnum(severityNames, "severity", name)
+}
+
+func (s Severity) String() string {
+	return enumString(severityNames, "Severity", s)
+}
+
+// Compare returns -1, 0 or 1 when the severity is lower than, equal to or higher than the other
+func (s Severity) Compare(other Severity) int {
+	switch {
+	case s < other:
+		return -1
+	case s > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast returns whether the severity is the same or higher than the other
+func (s Severity) AtLeast(other Severity) bool {
+	return s.Compare(other) >= 0
+}
+
+// SubmissionSeverity is the severity of a submission together with the CVSS vector it was scored with
+type SubmissionSeverity struct {
+	ID     Severity `json:"id"`
+	Vector string   `json:"vector"`
+	Value  string   `json:"value"`
+}
+
+// CVSS parses the CVSS vector of the severity, it returns nil when no vector was set
+func (s SubmissionSeverity) CVSS() (*cvss.Vector, error) {
+	if strings.TrimSpace(s.Vector) == "" {
+		return nil, nil
+	}
+
+	vector, err := cvss.Parse(s.Vector)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid CVSS vector")
+	}
+
+	return vector, nil
+}
+
+type ProgramStatus int
+
+const (
+	ProgramStatusDraft     ProgramStatus = 1
+	ProgramStatusOpen      ProgramStatus = 2
+	ProgramStatusSuspended ProgramStatus = 3
+	ProgramStatusClosing   ProgramStatus = 4
+	ProgramStatusClosed    ProgramStatus = 5
+	ProgramStatusArchived  ProgramStatus = 6
+)
+
+var programStatusNames = map[ProgramStatus]string{
+	ProgramStatusDraft:     "Draft",
+	ProgramStatusOpen:      "Open",
+	ProgramStatusSuspended: "Suspended",
+	ProgramStatusClosing:   "Closing",
+	ProgramStatusClosed:    "Closed",
+	ProgramStatusArchived:  "Archived",
+}
+
+// ParseProgramStatus returns the program status for its name, such as 'open'
+func ParseProgramStatus(name string) (ProgramStatus, error) {
+	return parseEnum(programStatusNames, "program status", name)
+}
+
+func (s ProgramStatus) String() string {
+	return enumString(programStatusNames, "ProgramStatus", s)
+}
+
+type ProgramType int
+
+const (
+	ProgramTypeBugBounty     ProgramType = 1
+	ProgramTypeHybridPentest ProgramType = 2
+)
+
+var programTypeNames = map[ProgramType]string{
+	ProgramTypeBugBounty:     "Bug bounty",
+	ProgramTypeHybridPentest: "Hybrid pentest",
+}
+
+// ParseProgramType returns the program type for its name, such as 'bug-bounty'
+func ParseProgramType(name string) (ProgramType, error) {
+	return parseEnum(programTypeNames, "program type", name)
+}
+
+func (t ProgramType) String() string {
+	return enumString(programTypeNames, "ProgramType", t)
+}
+
+type ConfidentialityLevel int
+
+const (
+	ConfidentialityLevelInviteOnly  ConfidentialityLevel = 1
+	ConfidentialityLevelApplication ConfidentialityLevel = 2
+	ConfidentialityLevelRegistered  ConfidentialityLevel = 3
+	ConfidentialityLevelPublic      ConfidentialityLevel = 4
+)
+
+var confidentialityLevelNames = map[ConfidentialityLevel]string{
+	ConfidentialityLevelInviteOnly:  "Invite only",
+	ConfidentialityLevelApplication: "Application",
+	ConfidentialityLevelRegistered:  "Registered",
+	ConfidentialityLevelPublic:      "Public",
+}
+
+// ParseConfidentialityLevel returns the confidentiality level for its name, such as 'invite-only'
+func ParseConfidentialityLevel(name string) (ConfidentialityLevel, error) {
+	return parseEnum(confidentialityLevelNames, "confidentiality level", name)
+}
+
+func (c ConfidentialityLevel) String() string {
+	return enumString(confidentialityLevelNames, "ConfidentialityLevel", c)
+}
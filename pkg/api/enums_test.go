@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumValueJSON(t *testing.T) {
+	var program Program
+	if err := json.Unmarshal([]byte(`{"status":{"id":2,"value":"Open for business"},"confidentialityLevel":4,"type":null}`), &program); err != nil {
+		t.Fatal(err)
+	}
+
+	if program.Status.ID != ProgramStatusOpen || program.Status.String() != "Open for business" {
+		t.Errorf("unexpected status %#v", program.Status)
+	}
+
+	if program.ConfidentialityLevel.ID != ConfidentialityLevelPublic || program.ConfidentialityLevel.String() != "Public" {
+		t.Errorf("unexpected confidentiality level %#v", program.ConfidentialityLevel)
+	}
+
+	if program.Type.ID != 0 || program.Type.String() != "ProgramType(0)" {
+		t.Errorf("unexpected type %#v", program.Type)
+	}
+
+	b, err := json.Marshal(program.Status)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != `{"id":2,"value":"Open for business"}` {
+		t.Errorf("unexpected encoding %s", b)
+	}
+
+	var decoded EnumValue[ProgramStatus]
+	if err := json.Unmarshal(b, &decoded); err != nil || decoded != program.Status {
+		t.Errorf("unexpected round trip %#v: %v", decoded, err)
+	}
+}
+
+func TestEnumString(t *testing.T) {
+	tests := map[string]string{
+		SubmissionStatusTriage.String():               "Triage",
+		CloseReasonOutOfScope.String():                "Out of scope",
+		SeverityCritical.String():                     "Critical",
+		ConfidentialityLevelInviteOnly.String():       "Invite only",
+		SubmissionStatus(42).String():                 "SubmissionStatus(42)",
+		EnumValue[Severity]{ID: SeverityLow}.String(): "Low",
+	}
+
+	for actual, expected := range tests {
+		if actual != expected {
+			t.Errorf("got '%s', expected '%s'", actual, expected)
+		}
+	}
+
+	for name, expected := range map[string]CloseReason{"Out of scope": CloseReasonOutOfScope, "accepted-risk": CloseReasonAcceptedRisk} {
+		if reason, err := ParseCloseReason(name); err != nil || reason != expected {
+			t.Errorf("unexpected close reason %s for '%s': %v", reason, name, err)
+		}
+	}
+
+	if _, err := ParseCloseReason("wontfix"); err == nil {
+		t.Error("expected an unknown close reason to be rejected")
+	}
+
+	for name, expected := range map[string]Severity{"critical": SeverityCritical, " Low ": SeverityLow, "EXCEPTIONAL": SeverityExceptional} {
+		if severity, err := ParseSeverity(name); err != nil || severity != expected {
+			t.Errorf("unexpected severity %s for '%s': %v", severity, name, err)
+		}
+	}
+
+	if _, err := ParseSeverity("severe"); err == nil {
+		t.Error("expected an unknown severity to be rejected")
+	}
+
+	if !SeverityHigh.AtLeast(SeverityMedium) || SeverityLow.AtLeast(SeverityHigh) || SeverityHigh.Compare(SeverityHigh) != 0 {
+		t.Error("unexpected severity ordering")
+	}
+}
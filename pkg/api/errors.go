@@ -0,0 +1,172 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// the maximum amount of bytes we keep from an error response body
+	maxErrorBodyBytes = 64 * 1024
+	// the amount of body characters shown in an error message
+	maxErrorMessageChars = 200
+)
+
+var (
+	// ErrNotFound is matched by an APIError with status 404
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized is matched by an APIError with status 401
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is matched by an APIError with status 403
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited is matched by an APIError with status 429
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServerError is matched by an APIError with a 5xx status
+	ErrServerError = errors.New("server error")
+)
+
+// AuthorizationError is returned when the login redirects back with an error instead of a code, e.g. access_denied
+// see RFC 6749 section 4.1.2.1
+type AuthorizationError struct {
+	Code        string
+	Description string
+	URI         string
+}
+
+func (e *AuthorizationError) Error() string {
+	msg := "authorization failed: " + e.Code
+
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+
+	if e.URI != "" {
+		msg += " (" + e.URI + ")"
+	}
+
+	return msg
+}
+
+// ProblemDetails is the RFC 7807 error body returned by the Intigriti API
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+	TraceID  string `json:"traceId"`
+}
+
+// APIError is returned for every non-successful response of the Intigriti API
+// use errors.Is with the Err* sentinels to classify it, or errors.As to inspect it
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       []byte
+	Problem    *ProblemDetails
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s returned status %d", e.Method, e.URL, e.StatusCode)
+
+	if e.Problem != nil && e.Problem.Detail != "" {
+		msg += ": " + e.Problem.Detail
+	} else if e.Problem != nil && e.Problem.Title != "" {
+		msg += ": " + e.Problem.Title
+	} else if body := strings.TrimSpace(string(e.Body)); body != "" {
+		if len(body) > maxErrorMessageChars {
+			body = body[:maxErrorMessageChars] + "..."
+		}
+		msg += ": " + body
+	}
+
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
+	}
+
+	return msg
+}
+
+// Is allows errors.Is to match the error against our sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// newAPIError builds an APIError from a non-successful response, consuming its body
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("x-request-id"),
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = resp.Request.URL.String()
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	if err == nil {
+		apiErr.Body = b
+	}
+
+	var problem ProblemDetails
+	if len(b) > 0 && json.Unmarshal(b, &problem) == nil && (problem.Title != "" || problem.Detail != "") {
+		apiErr.Problem = &problem
+
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = problem.TraceID
+		}
+	}
+
+	return apiErr
+}
+
+// fromRetrieveError converts an error of the oauth2 token endpoint into an APIError
+// other errors are returned as-is
+func fromRetrieveError(err error) error {
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) || retrieveErr.Response == nil {
+		return err
+	}
+
+	apiErr := &APIError{
+		StatusCode: retrieveErr.Response.StatusCode,
+		Body:       retrieveErr.Body,
+		RequestID:  retrieveErr.Response.Header.Get("x-request-id"),
+	}
+
+	if retrieveErr.Response.Request != nil {
+		apiErr.Method = retrieveErr.Response.Request.Method
+		apiErr.URL = retrieveErr.Response.Request.URL.String()
+	}
+
+	if retrieveErr.ErrorCode != "" {
+		apiErr.Problem = &ProblemDetails{
+			Title:  retrieveErr.ErrorCode,
+			Detail: retrieveErr.ErrorDescription,
+			Status: retrieveErr.Response.StatusCode,
+		}
+	}
+
+	return apiErr
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
 	"net/http"
 	"net/http/httputil"
 )
@@ -41,3 +43,23 @@ func (t TaggedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 
 	return resp, err
 }
+
+type tokenRoundTripper struct {
+	Proxied http.RoundTripper
+	Token   func(ctx context.Context) (*oauth2.Token, error)
+}
+
+// RoundTrip sets the authorization header on every request
+// the token is retrieved, and refreshed if needed, using the request context
+func (t tokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Token(req.Context())
+	if err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	authReq := req.Clone(req.Context())
+	token.SetAuthHeader(authReq)
+
+	return t.Proxied.RoundTrip(authReq)
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"github.com/hazcod/go-intigriti/pkg/config"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRequestsPerSecond = 10
+	defaultMaxInFlight       = 10
+
+	// reset values above this are unix timestamps instead of a delay in seconds
+	rateLimitResetEpochThreshold = 1_000_000_000
+)
+
+// rateLimiter is a token bucket combined with a maximum of concurrent requests
+// it is shared by every copy of the Endpoint so the budget holds across goroutines
+type rateLimiter struct {
+	mu sync.Mutex
+
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+
+	inFlight chan struct{}
+}
+
+func newRateLimiter(cfg config.RateLimitConfig) *rateLimiter {
+	l := &rateLimiter{
+		rate:  cfg.RequestsPerSecond,
+		burst: float64(cfg.Burst),
+		last:  time.Now(),
+	}
+
+	if l.rate == 0 {
+		l.rate = defaultRequestsPerSecond
+	}
+
+	if l.burst <= 0 {
+		l.burst = max(1, l.rate)
+	}
+
+	l.tokens = l.burst
+
+	maxInFlight := cfg.MaxInFlight
+	if maxInFlight == 0 {
+		maxInFlight = defaultMaxInFlight
+	}
+
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+
+	return l
+}
+
+// wait blocks until a request may be sent according to the request rate
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+
+	now := time.Now()
+	var wait time.Duration
+
+	if l.rate > 0 {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+
+		// reserve our token, a negative balance is the time we have to wait for it
+		l.tokens--
+		if l.tokens < 0 {
+			wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+
+	if pause := l.pausedUntil.Sub(now); pause > wait {
+		wait = pause
+	}
+
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.rate > 0 {
+			l.tokens++
+		}
+		l.mu.Unlock()
+
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// acquire blocks until the amount of in-flight requests allows another one
+func (l *rateLimiter) acquire(ctx context.Context) error {
+	if l.inFlight == nil {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case l.inFlight <- struct{}{}:
+		return nil
+	}
+}
+
+func (l *rateLimiter) release() {
+	if l.inFlight != nil {
+		<-l.inFlight
+	}
+}
+
+// pauseUntil holds back all new requests until the given time
+func (l *rateLimiter) pauseUntil(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+type rateLimitedRoundTripper struct {
+	Proxied http.RoundTripper
+	Logger  *logrus.Logger
+	Limiter *rateLimiter
+}
+
+// RoundTrip waits for the request budget before sending and adapts it to rate limit response headers
+// the in-flight slot is held until the response body is closed
+func (t rateLimitedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.wait(req.Context()); err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	if err := t.Limiter.acquire(req.Context()); err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	resp, err := t.Proxied.RoundTrip(req)
+	if err != nil {
+		t.Limiter.release()
+		return nil, err
+	}
+
+	if until, ok := rateLimitedUntil(resp); ok {
+		t.Logger.WithField("until", until.String()).Debug("rate limit reached, pausing requests")
+		t.Limiter.pauseUntil(until)
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.Limiter.release}
+
+	return resp, nil
+}
+
+// rateLimitedUntil returns until when the API asks us to stop sending requests, if it does
+func rateLimitedUntil(resp *http.Response) (time.Time, bool) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			return time.Now().Add(retryAfter), true
+		}
+	}
+
+	remaining := firstHeader(resp.Header, "RateLimit-Remaining", "X-RateLimit-Remaining")
+	if remaining != "0" {
+		return time.Time{}, false
+	}
+
+	reset, err := strconv.ParseInt(firstHeader(resp.Header, "RateLimit-Reset", "X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}, false
+	}
+
+	if reset > rateLimitResetEpochThreshold {
+		return time.Unix(reset, 0), true
+	}
+
+	return time.Now().Add(time.Duration(reset) * time.Second), true
+}
+
+func firstHeader(header http.Header, names ...string) string {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// releasingBody releases the in-flight slot once the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hazcod/go-intigriti/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+// newRateLimitedClient returns a client sending its requests to the handler through a rate limiter
+func newRateLimitedClient(t *testing.T, cfg config.RateLimitConfig, handler http.HandlerFunc) (*http.Client, string) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &http.Client{Transport: rateLimitedRoundTripper{
+		Proxied: http.DefaultTransport,
+		Logger:  logrus.New(),
+		Limiter: newRateLimiter(cfg),
+	}}, srv.URL
+}
+
+func sendRequest(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Do(req)
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	client, url := newRateLimitedClient(t, config.RateLimitConfig{RequestsPerSecond: 20, Burst: 2, MaxInFlight: -1},
+		func(http.ResponseWriter, *http.Request) {})
+
+	start := time.Now()
+
+	for i := 0; i < 4; i++ {
+		resp, err := sendRequest(context.Background(), client, url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// the burst goes out at once, the other two wait 50ms each for a new token
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected the requests after the burst to be limited, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterReleasesSlotOnClose(t *testing.T) {
+	client, url := newRateLimitedClient(t, config.RateLimitConfig{RequestsPerSecond: -1, MaxInFlight: 1},
+		func(http.ResponseWriter, *http.Request) {})
+
+	first, err := sendRequest(context.Background(), client, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := sendRequest(ctx, client, url); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the second request to wait for the open response, got %v", err)
+	}
+
+	// closing twice must not release the slot of another request
+	_ = first.Body.Close()
+	_ = first.Body.Close()
+
+	for i := 0; i < 2; i++ {
+		resp, err := sendRequest(context.Background(), client, url)
+		if err != nil {
+			t.Fatalf("expected the slot to be released: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if limiter := client.Transport.(rateLimitedRoundTripper).Limiter; len(limiter.inFlight) != 0 {
+		t.Errorf("expected no slots in use, got %d", len(limiter.inFlight))
+	}
+}
+
+func TestRateLimiterPausesOnTooManyRequests(t *testing.T) {
+	var requests atomic.Int32
+
+	client, url := newRateLimitedClient(t, config.RateLimitConfig{RequestsPerSecond: -1, MaxInFlight: -1},
+		func(w http.ResponseWriter, _ *http.Request) {
+			if requests.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		})
+
+	resp, err := sendRequest(context.Background(), client, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	start := time.Now()
+
+	if resp, err = sendRequest(context.Background(), client, url); err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected requests to be paused for the Retry-After delay, took %s", elapsed)
+	}
+}
+
+func TestRateLimitedUntil(t *testing.T) {
+	header := func(values ...string) http.Header {
+		h := http.Header{}
+		for i := 0; i < len(values); i += 2 {
+			h.Set(values[i], values[i+1])
+		}
+		return h
+	}
+
+	tests := map[string]struct {
+		status int
+		header http.Header
+		paused bool
+	}{
+		"ok":                {http.StatusOK, header(), false},
+		"remaining":         {http.StatusOK, header("RateLimit-Remaining", "3", "RateLimit-Reset", "10"), false},
+		"exhausted":         {http.StatusOK, header("RateLimit-Remaining", "0", "RateLimit-Reset", "10"), true},
+		"exhausted legacy":  {http.StatusOK, header("X-RateLimit-Remaining", "0", "X-RateLimit-Reset", "10"), true},
+		"exhausted no time": {http.StatusOK, header("RateLimit-Remaining", "0"), false},
+		"too many requests": {http.StatusTooManyRequests, header("Retry-After", "5"), true},
+	}
+
+	for name, test := range tests {
+		until, paused := rateLimitedUntil(&http.Response{StatusCode: test.status, Header: test.header})
+		if paused != test.paused || (paused && !until.After(time.Now())) {
+			t.Errorf("%s: unexpected pause until %s (%v)", name, until, paused)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"github.com/hazcod/go-intigriti/pkg/config"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+)
+
+type RetryRoundTripper struct {
+	Proxied http.RoundTripper
+	Logger  *logrus.Logger
+	Config  config.RetryConfig
+}
+
+// RoundTrip retries requests failing with a transient error using exponential backoff and jitter
+// non-idempotent requests are only retried if enabled in the configuration
+func (t RetryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxRetries := t.Config.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	canRetry := maxRetries > 0 && t.isRetryable(req)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+
+		if attempt > 0 {
+			var err error
+			if attemptReq, err = rewindRequest(req); err != nil {
+				return nil, errors.Wrap(err, "could not rewind request body")
+			}
+		}
+
+		resp, err := t.Proxied.RoundTrip(attemptReq)
+
+		if !canRetry || attempt >= maxRetries || !isTransient(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			// don't block longer than we are allowed to, the caller gets the response instead
+			if retryAfter > t.maxBackoff() {
+				return resp, err
+			}
+
+			wait = retryAfter
+		}
+
+		logger := t.logger().WithField("attempt", attempt+1).WithField("wait", wait.String()).
+			WithField("url", req.URL.String())
+		if err != nil {
+			logger.WithError(err).Debug("request failed, retrying")
+		} else {
+			logger.WithField("status", resp.StatusCode).Debug("request returned transient status, retrying")
+			drainBody(resp)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t RetryRoundTripper) logger() *logrus.Logger {
+	if t.Logger == nil {
+		return logrus.StandardLogger()
+	}
+
+	return t.Logger
+}
+
+func (t RetryRoundTripper) maxBackoff() time.Duration {
+	if t.Config.MaxBackoff <= 0 {
+		return defaultMaxBackoff
+	}
+
+	return t.Config.MaxBackoff
+}
+
+// backoff returns the exponential backoff for the given attempt with jitter applied
+func (t RetryRoundTripper) backoff(attempt int) time.Duration {
+	minBackoff := t.Config.MinBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultMinBackoff
+	}
+
+	wait := t.maxBackoff()
+	if attempt < 32 && minBackoff<<attempt < wait && minBackoff<<attempt > 0 {
+		wait = minBackoff << attempt
+	}
+
+	// wait anywhere between half and the full backoff to spread out concurrent clients
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// noRetryContextKey marks requests which must never be sent twice
+type noRetryContextKey struct{}
+
+// withoutRetry returns a context whose requests are never retried, not even with RetryUnsafeMethods
+// used for requests where a duplicate is harmful, such as a payout being made twice
+func withoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryContextKey{}, true)
+}
+
+// isRetryable returns whether the request may be sent more than once
+func (t RetryRoundTripper) isRetryable(req *http.Request) bool {
+	if noRetry, _ := req.Context().Value(noRetryContextKey{}).(bool); noRetry {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if t.Config.RetryUnsafeMethods {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransient returns whether the outcome of the request is worth retrying
+func isTransient(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// the caller gave up, so should we
+		return req.Context().Err() == nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses the Retry-After header, either in seconds or as a http date
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// rewindRequest returns a copy of the request with a fresh body
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	rewound := req.Clone(req.Context())
+	rewound.Body = body
+
+	return rewound, nil
+}
+
+// drainBody reads a bit of the response body and closes it so the connection can be reused
+func drainBody(resp *http.Response) {
+	_, _ = io.CopyN(io.Discard, resp.Body, 4096)
+	_ = resp.Body.Close()
+}
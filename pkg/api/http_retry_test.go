@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hazcod/go-intigriti/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+func TestRetryRoundTripperWithoutRetry(t *testing.T) {
+	var attempts atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: RetryRoundTripper{
+		Proxied: http.DefaultTransport,
+		Logger:  logrus.New(),
+		Config:  config.RetryConfig{MaxRetries: 2, MinBackoff: time.Millisecond, RetryUnsafeMethods: true},
+	}}
+
+	send := func(ctx context.Context) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL, strings.NewReader("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	send(context.Background())
+	if attempts.Load() != 3 {
+		t.Errorf("expected the request to be retried twice, got %d attempts", attempts.Load())
+	}
+
+	attempts.Store(0)
+
+	send(withoutRetry(context.Background()))
+	if attempts.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts.Load())
+	}
+}
+
+// newRetryingTestEndpoint returns an endpoint sending its API requests through the retry transport to a failing API
+func newRetryingTestEndpoint(t *testing.T, attempts *atomic.Int32) *Endpoint {
+	e := newTestEndpoint(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	e.client = &http.Client{Transport: RetryRoundTripper{
+		Proxied: http.DefaultTransport,
+		Logger:  e.logger,
+		Config:  config.RetryConfig{MaxRetries: 2, MinBackoff: time.Millisecond},
+	}}
+
+	return e
+}
+
+func TestMessagesAreNotRetried(t *testing.T) {
+	tests := map[string]func(e *Endpoint) error{
+		"accept": func(e *Endpoint) error { return e.AcceptSubmission(context.Background(), "CODE", "Thanks") },
+		"close": func(e *Endpoint) error {
+			return e.CloseSubmission(context.Background(), "CODE", CloseReasonDuplicate, "Duplicate")
+		},
+		"archive": func(e *Endpoint) error { return e.ArchiveSubmission(context.Background(), "CODE", "Archived") },
+		"reopen":  func(e *Endpoint) error { return e.ReopenSubmission(context.Background(), "CODE", "Reopened") },
+		"message": func(e *Endpoint) error { return e.PostSubmissionMessage(context.Background(), "CODE", "Hello") },
+		"note":    func(e *Endpoint) error { return e.PostSubmissionNote(context.Background(), "CODE", "Internal") },
+		"feedback": func(e *Endpoint) error {
+			return e.RequestSubmissionFeedback(context.Background(), "CODE", "More details please")
+		},
+	}
+
+	for name, send := range tests {
+		var attempts atomic.Int32
+		e := newRetryingTestEndpoint(t, &attempts)
+
+		if err := send(e); err == nil {
+			t.Errorf("%s: expected the failure to be returned", name)
+		}
+
+		if attempts.Load() != 1 {
+			t.Errorf("%s: expected a single attempt, got %d", name, attempts.Load())
+		}
+	}
+}
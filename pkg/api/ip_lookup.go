@@ -1,9 +1,8 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"github.com/pkg/errors"
-	"io"
 	"net"
 	"net/http"
 )
@@ -16,34 +15,24 @@ const (
 // IsKnownIP verifies whether the IP address is known to the Intigriti platform
 // this can be as a researcher or company account
 func (e *Endpoint) IsKnownIP(ip net.IP) (bool, error) {
-	req, err := http.NewRequest(http.MethodGet, apiURL+ipLookupURI, nil)
+	return e.IsKnownIPWithContext(context.Background(), ip)
+}
+
+// IsKnownIPWithContext verifies whether the IP address is known to the Intigriti platform
+// this can be as a researcher or company account
+func (e *Endpoint) IsKnownIPWithContext(ctx context.Context, ip net.IP) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+ipLookupURI, nil)
 	if err != nil {
-		return false, errors.Wrap(err, "could not create get programs")
+		return false, errors.Wrap(err, "could not create ip lookup request")
 	}
 
 	queryValues := req.URL.Query()
 	queryValues.Set(ipLookupParamName, ip.String())
 	req.URL.RawQuery = queryValues.Encode()
 
-	resp, err := e.client.Do(req)
-	if err != nil {
-		return false, errors.Wrap(err, "could not get programs")
-	}
-
-	if resp.StatusCode > 399 {
-		return false, errors.Errorf("returned status %d", resp.StatusCode)
-	}
-
-	defer resp.Body.Close()
-
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return false, errors.Wrap(err, "could not read response")
-	}
-
 	var ipResponse lookupIPResponse
-	if err := json.Unmarshal(b, &ipResponse); err != nil {
-		return false, errors.Wrap(err, "could not decode response")
+	if err := e.doRequest(req, &ipResponse); err != nil {
+		return false, errors.Wrap(err, "could not lookup ip address")
 	}
 
 	return ipResponse.Exists, nil
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// the amount of records fetched per page if no page size is given
+	defaultPageSize = 50
+	// the maximum amount of records the API returns per page
+	maxPageSize = 500
+
+	pageOffsetParamName = "offset"
+	pageLimitParamName  = "limit"
+	pageCursorParamName = "cursor"
+)
+
+// PageOptions configures how paged API resources are retrieved
+type PageOptions struct {
+	// PageSize is the amount of records retrieved per request, defaults to 50 and is capped at 500
+	PageSize int
+}
+
+func (o *PageOptions) pageSize() int {
+	if o == nil || o.PageSize <= 0 {
+		return defaultPageSize
+	}
+
+	if o.PageSize > maxPageSize {
+		return maxPageSize
+	}
+
+	return o.PageSize
+}
+
+// pagedResponse is the envelope of the offset/limit paged API resources
+type pagedResponse[T any] struct {
+	MaxCount int `json:"maxCount"`
+	Records  []T `json:"records"`
+}
+
+// paginate lazily iterates over every record of an offset/limit paged API resource
+// a page is only requested once all records of the previous page have been consumed
+func paginate[T any](ctx context.Context, e *Endpoint, uri string, opts *PageOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		limit := opts.pageSize()
+
+		for offset := 0; ; {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+uri, nil)
+			if err != nil {
+				yield(zero, errors.Wrap(err, "could not create page request"))
+				return
+			}
+
+			queryValues := req.URL.Query()
+			queryValues.Set(pageOffsetParamName, strconv.Itoa(offset))
+			queryValues.Set(pageLimitParamName, strconv.Itoa(limit))
+			req.URL.RawQuery = queryValues.Encode()
+
+			e.logger.WithField("uri", uri).WithField("offset", offset).Debug("retrieving page")
+
+			var page pagedResponse[T]
+			if err := e.doRequest(req, &page); err != nil {
+				yield(zero, errors.Wrapf(err, "could not get page at offset %d", offset))
+				return
+			}
+
+			for _, record := range page.Records {
+				if !yield(record, nil) {
+					return
+				}
+			}
+
+			offset += len(page.Records)
+
+			// a missing maxCount decodes as 0, in which case only a short page ends the iteration
+			if len(page.Records) < limit || (page.MaxCount > 0 && offset >= page.MaxCount) {
+				return
+			}
+		}
+	}
+}
+
+// cursorResponse is the envelope of the cursor paged API resources
+type cursorResponse[T any] struct {
+	Records    []T    `json:"records"`
+	NextCursor string `json:"nextCursor"`
+}
+
+// paginateCursor lazily iterates over every record of a cursor paged API resource
+// the query values are sent with every page request, a page is only requested once the previous one was consumed
+func paginateCursor[T any](ctx context.Context, e *Endpoint, uri string, query url.Values, opts *PageOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		cursor := ""
+
+		for {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+uri, nil)
+			if err != nil {
+				yield(zero, errors.Wrap(err, "could not create page request"))
+				return
+			}
+
+			queryValues := req.URL.Query()
+			for name, values := range query {
+				queryValues[name] = values
+			}
+
+			queryValues.Set(pageLimitParamName, strconv.Itoa(opts.pageSize()))
+			if cursor != "" {
+				queryValues.Set(pageCursorParamName, cursor)
+			}
+
+			req.URL.RawQuery = queryValues.Encode()
+
+			e.logger.WithField("uri", uri).WithField("cursor", cursor).Debug("retrieving page")
+
+			var page cursorResponse[T]
+			if err := e.doRequest(req, &page); err != nil {
+				yield(zero, errors.Wrap(err, "could not get page"))
+				return
+			}
+
+			for _, record := range page.Records {
+				if !yield(record, nil) {
+					return
+				}
+			}
+
+			if page.NextCursor == "" || page.NextCursor == cursor || len(page.Records) == 0 {
+				return
+			}
+
+			cursor = page.NextCursor
+		}
+	}
+}
+
+// collect retrieves all records of the iterator, stopping at the first error
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	records := make([]T, 0)
+
+	for record, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// newTestEndpoint returns an endpoint sending its API requests to the handler
+func newTestEndpoint(t *testing.T, handler http.Handler) *Endpoint {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	previousURL := apiURL
+	apiURL = srv.URL
+	t.Cleanup(func() { apiURL = previousURL })
+
+	return &Endpoint{logger: logrus.New(), client: srv.Client()}
+}
+
+func TestPaginateWithoutMaxCount(t *testing.T) {
+	const total = 5
+
+	e := newTestEndpoint(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get(pageOffsetParamName))
+		limit, _ := strconv.Atoi(r.URL.Query().Get(pageLimitParamName))
+
+		records := "["
+		for i := offset; i < min(offset+limit, total); i++ {
+			if i > offset {
+				records += ","
+			}
+			records += strconv.Itoa(i)
+		}
+
+		// no maxCount in the envelope
+		_, _ = fmt.Fprintf(w, `{"records":%s]}`, records)
+	}))
+
+	var received []int
+	for record, err := range paginate[int](context.Background(), e, "/records", &PageOptions{PageSize: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		received = append(received, record)
+	}
+
+	if len(received) != total {
+		t.Errorf("expected %d records, got %v", total, received)
+	}
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	programActivitiesUri          = "/company/v2/programs/%s/activities"
+	activityCreatedSinceParamName = "createdSince"
+)
+
+type ProgramActivityType int
+
+const (
+	ProgramActivityDomainsUpdated           ProgramActivityType = 1
+	ProgramActivityRulesOfEngagementUpdated ProgramActivityType = 2
+	ProgramActivityBountyTableUpdated       ProgramActivityType = 3
+	ProgramActivityStatusChanged            ProgramActivityType = 4
+	ProgramActivitySubmissionCreated        ProgramActivityType = 5
+)
+
+type ProgramActivity struct {
+	ID   string `json:"id"`
+	Type struct {
+		ID    ProgramActivityType `json:"id"`
+		Value string              `json:"value"`
+	} `json:"type"`
+	ProgramID string `json:"programId"`
+	CreatedAt int    `json:"createdAt"`
+	User      struct {
+		UserID   string `json:"userId"`
+		UserName string `json:"userName"`
+		Role     string `json:"role"`
+	} `json:"user"`
+	Description string `json:"description"`
+	// SubmissionCode is set for submission activities
+	SubmissionCode string `json:"submissionCode"`
+	// VersionID is the new version for domain, rules of engagement and bounty table updates
+	VersionID string `json:"versionId"`
+}
+
+// GetProgramActivities returns all activity of the program since the given time, a zero time returns everything
+// every page is retrieved, use ProgramActivities to iterate over them lazily instead
+func (e *Endpoint) GetProgramActivities(ctx context.Context, programId string, since time.Time) ([]ProgramActivity, error) {
+	activities, err := collect(e.ProgramActivities(ctx, programId, since, nil))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get program activities")
+	}
+
+	return activities, nil
+}
+
+// ProgramActivities iterates over all activity of the program since the given time, a zero time returns everything
+// pages are retrieved lazily while iterating, iteration stops after the first error
+// activities are yielded in the order the API returns them, which is not guaranteed to be chronological
+func (e *Endpoint) ProgramActivities(ctx context.Context, programId string, since time.Time, opts *PageOptions) iter.Seq2[ProgramActivity, error] {
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set(activityCreatedSinceParamName, strconv.FormatInt(since.Unix(), 10))
+	}
+
+	return paginateCursor[ProgramActivity](ctx, e, fmt.Sprintf(programActivitiesUri, url.PathEscape(programId)), query, opts)
+}
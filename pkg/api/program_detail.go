@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+)
+
+const (
+	programDetailURI = "/company/v2/programs/%s"
+
+	// the tier given to out of scope domains
+	domainTierOutOfScope = 5
+)
+
+// GetProgram returns the full details of the program with the given identifier
+// this includes the current domains, rules of engagement and bounty tables
+func (e *Endpoint) GetProgram(ctx context.Context, programId string) (*ProgramDetail, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+fmt.Sprintf(programDetailURI, url.PathEscape(programId)), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create get program request")
+	}
+
+	var program ProgramDetail
+	if err := e.doRequest(req, &program); err != nil {
+		return nil, errors.Wrap(err, "could not get program")
+	}
+
+	return &program, nil
+}
+
+type ProgramDetail struct {
+	Program
+
+	Domains           ProgramDomains           `json:"domains"`
+	RulesOfEngagement ProgramRulesOfEngagement `json:"rulesOfEngagement"`
+	BountyTables      []ProgramBountyTable     `json:"bountyTables"`
+}
+
+// ProgramDomains is a version of the program scope, its ID is the version identifier
+type ProgramDomains struct {
+	ID        string          `json:"id"`
+	CreatedAt int             `json:"createdAt"`
+	Content   []ProgramDomain `json:"content"`
+}
+
+type ProgramDomain struct {
+	ID   string `json:"id"`
+	Type struct {
+		ID    int    `json:"id"`
+		Value string `json:"value"`
+	} `json:"type"`
+	Endpoint string `json:"endpoint"`
+	Tier     struct {
+		ID    int    `json:"id"`
+		Value string `json:"value"`
+	} `json:"tier"`
+	Description string `json:"description"`
+}
+
+// InScope returns whether the domain may be tested, out of scope domains are listed with the 'No bounty' tier
+func (d *ProgramDomain) InScope() bool {
+	return d.Tier.ID != domainTierOutOfScope
+}
+
+// ProgramRulesOfEngagement is a version of the program rules, its ID is the version identifier
+type ProgramRulesOfEngagement struct {
+	ID        string `json:"id"`
+	CreatedAt int    `json:"createdAt"`
+	Content   struct {
+		Description         string `json:"description"`
+		TestingRequirements struct {
+			IntigritiMe      bool `json:"intigritiMe"`
+			AutomatedTooling struct {
+				ID    int    `json:"id"`
+				Value string `json:"value"`
+			} `json:"automatedTooling"`
+			UserAgent     string `json:"userAgent"`
+			RequestHeader string `json:"requestHeader"`
+		} `json:"testingRequirements"`
+		SafeHarbour bool `json:"safeHarbour"`
+	} `json:"content"`
+}
+
+type ProgramBountyTable struct {
+	ID   string `json:"id"`
+	Tier struct {
+		ID    int    `json:"id"`
+		Value string `json:"value"`
+	} `json:"tier"`
+	Bounties []ProgramBounty `json:"bounties"`
+}
+
+type ProgramBounty struct {
+	Severity struct {
+		ID    int    `json:"id"`
+		Value string `json:"value"`
+	} `json:"severity"`
+	Amount struct {
+		Value    float64 `json:"value"`
+		Currency string  `json:"currency"`
+	} `json:"amount"`
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+type DiffOp string
+
+const (
+	DiffAdded     DiffOp = "+"
+	DiffRemoved   DiffOp = "-"
+	DiffUnchanged DiffOp = " "
+)
+
+type DomainsDiff struct {
+	Added   []ProgramDomain
+	Removed []ProgramDomain
+	Changed []DomainChange
+}
+
+type DomainChange struct {
+	From ProgramDomain
+	To   ProgramDomain
+}
+
+// IsEmpty returns whether both domain versions have the same scope
+func (d DomainsDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffDomains compares two versions of the program domains
+// domains are matched on their type and endpoint, a different tier or description is a change
+func DiffDomains(from, to ProgramDomains) DomainsDiff {
+	domainKey := func(d ProgramDomain) string {
+		return fmt.Sprintf("%d|%s", d.Type.ID, strings.ToLower(strings.TrimSpace(d.Endpoint)))
+	}
+
+	var diff DomainsDiff
+
+	previous := make(map[string]ProgramDomain, len(from.Content))
+	for _, domain := range from.Content {
+		previous[domainKey(domain)] = domain
+	}
+
+	for _, domain := range to.Content {
+		key := domainKey(domain)
+
+		old, found := previous[key]
+		if !found {
+			diff.Added = append(diff.Added, domain)
+			continue
+		}
+
+		delete(previous, key)
+
+		if old.Tier.ID != domain.Tier.ID || old.Description != domain.Description {
+			diff.Changed = append(diff.Changed, DomainChange{From: old, To: domain})
+		}
+	}
+
+	// keep the order of the original version for removed domains
+	for _, domain := range from.Content {
+		if _, removed := previous[domainKey(domain)]; removed {
+			diff.Removed = append(diff.Removed, domain)
+		}
+	}
+
+	return diff
+}
+
+type RulesOfEngagementDiff struct {
+	// Description is a line diff of the rules text, including unchanged lines
+	Description []LineChange
+	// Requirements lists the testing requirements and settings which changed
+	Requirements []FieldChange
+}
+
+type LineChange struct {
+	Op   DiffOp
+	Line string
+}
+
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// IsEmpty returns whether both rules of engagement versions are the same
+func (d RulesOfEngagementDiff) IsEmpty() bool {
+	if len(d.Requirements) > 0 {
+		return false
+	}
+
+	for _, change := range d.Description {
+		if change.Op != DiffUnchanged {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DiffRulesOfEngagement compares two versions of the program rules of engagement
+func DiffRulesOfEngagement(from, to ProgramRulesOfEngagement) RulesOfEngagementDiff {
+	diff := RulesOfEngagementDiff{
+		Description: diffLines(from.Content.Description, to.Content.Description),
+	}
+
+	oldReq, newReq := from.Content.TestingRequirements, to.Content.TestingRequirements
+
+	fields := []FieldChange{
+		{Field: "safe harbour", From: fmt.Sprint(from.Content.SafeHarbour), To: fmt.Sprint(to.Content.SafeHarbour)},
+		{Field: "intigriti.me accounts", From: fmt.Sprint(oldReq.IntigritiMe), To: fmt.Sprint(newReq.IntigritiMe)},
+		{Field: "automated tooling", From: oldReq.AutomatedTooling.Value, To: newReq.AutomatedTooling.Value},
+		{Field: "user agent", From: oldReq.UserAgent, To: newReq.UserAgent},
+		{Field: "request header", From: oldReq.RequestHeader, To: newReq.RequestHeader},
+	}
+
+	for _, field := range fields {
+		if field.From != field.To {
+			diff.Requirements = append(diff.Requirements, field)
+		}
+	}
+
+	return diff
+}
+
+// diffLines returns a line diff based on the longest common subsequence of both texts
+func diffLines(from, to string) []LineChange {
+	a, b := splitLines(from), splitLines(to)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	changes := make([]LineChange, 0, max(len(a), len(b)))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			changes = append(changes, LineChange{Op: DiffUnchanged, Line: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			changes = append(changes, LineChange{Op: DiffRemoved, Line: a[i]})
+			i++
+		default:
+			changes = append(changes, LineChange{Op: DiffAdded, Line: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		changes = append(changes, LineChange{Op: DiffRemoved, Line: a[i]})
+	}
+
+	for ; j < len(b); j++ {
+		changes = append(changes, LineChange{Op: DiffAdded, Line: b[j]})
+	}
+
+	return changes
+}
+
+// splitLines returns the lines of the text, an empty text having none
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffDomains(t *testing.T) {
+	domain := func(typeID int, endpoint string, tierID int) ProgramDomain {
+		var d ProgramDomain
+		d.Type.ID = typeID
+		d.Endpoint = endpoint
+		d.Tier.ID = tierID
+		return d
+	}
+
+	endpoints := func(domains []ProgramDomain) []string {
+		var result []string
+		for _, d := range domains {
+			result = append(result, d.Endpoint)
+		}
+		return result
+	}
+
+	tests := map[string]struct {
+		from, to                []ProgramDomain
+		added, removed, changed []string
+	}{
+		"empty": {},
+		"unchanged": {
+			from: []ProgramDomain{domain(1, "app.example.com", 1)},
+			to:   []ProgramDomain{domain(1, " APP.example.com ", 1)},
+		},
+		"added to empty": {
+			to:    []ProgramDomain{domain(1, "app.example.com", 1)},
+			added: []string{"app.example.com"},
+		},
+		"all removed": {
+			from:    []ProgramDomain{domain(1, "a.example.com", 1), domain(1, "b.example.com", 2)},
+			removed: []string{"a.example.com", "b.example.com"},
+		},
+		"tier changed": {
+			from:    []ProgramDomain{domain(1, "app.example.com", 1)},
+			to:      []ProgramDomain{domain(1, "app.example.com", 3)},
+			changed: []string{"app.example.com"},
+		},
+		"other type is another domain": {
+			from:    []ProgramDomain{domain(1, "example", 1)},
+			to:      []ProgramDomain{domain(2, "example", 1)},
+			added:   []string{"example"},
+			removed: []string{"example"},
+		},
+	}
+
+	for name, test := range tests {
+		diff := DiffDomains(ProgramDomains{Content: test.from}, ProgramDomains{Content: test.to})
+
+		var changed []string
+		for _, change := range diff.Changed {
+			changed = append(changed, change.To.Endpoint)
+		}
+
+		if !slices.Equal(endpoints(diff.Added), test.added) || !slices.Equal(endpoints(diff.Removed), test.removed) || !slices.Equal(changed, test.changed) {
+			t.Errorf("%s: unexpected diff %+v", name, diff)
+		}
+
+		if diff.IsEmpty() != (len(test.added)+len(test.removed)+len(test.changed) == 0) {
+			t.Errorf("%s: unexpected IsEmpty %v", name, diff.IsEmpty())
+		}
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := map[string]struct {
+		from, to string
+		expected []LineChange
+	}{
+		"both empty": {},
+		"from empty": {
+			to:       "a\nb",
+			expected: []LineChange{{DiffAdded, "a"}, {DiffAdded, "b"}},
+		},
+		"to empty": {
+			from:     "a",
+			expected: []LineChange{{DiffRemoved, "a"}},
+		},
+		"unchanged": {
+			from:     "a\r\nb",
+			to:       "a\nb",
+			expected: []LineChange{{DiffUnchanged, "a"}, {DiffUnchanged, "b"}},
+		},
+		"changed line": {
+			from:     "intro\nno automated tools\noutro",
+			to:       "intro\nautomated tools allowed\noutro\nnew rule",
+			expected: []LineChange{{DiffUnchanged, "intro"}, {DiffRemoved, "no automated tools"}, {DiffAdded, "automated tools allowed"}, {DiffUnchanged, "outro"}, {DiffAdded, "new rule"}},
+		},
+	}
+
+	for name, test := range tests {
+		if changes := diffLines(test.from, test.to); !slices.Equal(changes, test.expected) {
+			t.Errorf("%s: unexpected changes %v", name, changes)
+		}
+	}
+}
+
+func TestDiffRulesOfEngagement(t *testing.T) {
+	var from, to ProgramRulesOfEngagement
+	from.Content.Description = "rules"
+	to.Content.Description = "rules"
+	to.Content.TestingRequirements.UserAgent = "intigriti"
+
+	diff := DiffRulesOfEngagement(from, to)
+	if diff.IsEmpty() || len(diff.Requirements) != 1 || diff.Requirements[0].Field != "user agent" {
+		t.Errorf("unexpected diff %+v", diff)
+	}
+
+	if !DiffRulesOfEngagement(from, from).IsEmpty() {
+		t.Error("expected identical rules to have an empty diff")
+	}
+}
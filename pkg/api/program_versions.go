@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+)
+
+const (
+	programDomainsURI           = "/company/v2/programs/%s/domains/%s"
+	programRulesOfEngagementURI = "/company/v2/programs/%s/rules-of-engagements/%s"
+)
+
+// GetProgramDomains returns the given version of the program domains
+// the current version identifier is available in ProgramDetail.Domains.ID
+func (e *Endpoint) GetProgramDomains(ctx context.Context, programId, versionId string) (*ProgramDomains, error) {
+	uri := fmt.Sprintf(programDomainsURI, url.PathEscape(programId), url.PathEscape(versionId))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+uri, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create get program domains request")
+	}
+
+	var domains ProgramDomains
+	if err := e.doRequest(req, &domains); err != nil {
+		return nil, errors.Wrap(err, "could not get program domains")
+	}
+
+	return &domains, nil
+}
+
+// GetProgramRulesOfEngagement returns the given version of the program rules of engagement
+// the current version identifier is available in ProgramDetail.RulesOfEngagement.ID
+func (e *Endpoint) GetProgramRulesOfEngagement(ctx context.Context, programId, versionId string) (*ProgramRulesOfEngagement, error) {
+	uri := fmt.Sprintf(programRulesOfEngagementURI, url.PathEscape(programId), url.PathEscape(versionId))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+uri, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create get rules of engagement request")
+	}
+
+	var rules ProgramRulesOfEngagement
+	if err := e.doRequest(req, &rules); err != nil {
+		return nil, errors.Wrap(err, "could not get rules of engagement")
+	}
+
+	return &rules, nil
+}
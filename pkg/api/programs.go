@@ -1,9 +1,8 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"github.com/pkg/errors"
-	"io"
 	"net/http"
 )
 
@@ -13,56 +12,35 @@ const (
 
 // GetPrograms returns all Intigriti programs for the current company
 func (e *Endpoint) GetPrograms() ([]Program, error) {
-	req, err := http.NewRequest(http.MethodGet, apiURL+programURI, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create get programs")
-	}
-
-	resp, err := e.client.Do(req)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not get programs")
-	}
-
-	if resp.StatusCode > 399 {
-		return nil, errors.Errorf("returned status %d", resp.StatusCode)
-	}
-
-	defer resp.Body.Close()
+	return e.GetProgramsWithContext(context.Background())
+}
 
-	b, err := io.ReadAll(resp.Body)
+// GetProgramsWithContext returns all Intigriti programs for the current company
+func (e *Endpoint) GetProgramsWithContext(ctx context.Context) ([]Program, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+programURI, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not read response")
+		return nil, errors.Wrap(err, "could not create get programs request")
 	}
 
 	var programs []Program
-
-	if err := json.Unmarshal(b, &programs); err != nil {
-		return nil, errors.Wrap(err, "could not decode programs")
+	if err := e.doRequest(req, &programs); err != nil {
+		return nil, errors.Wrap(err, "could not get programs")
 	}
 
 	return programs, nil
 }
 
 type Program struct {
-	ID            string `json:"id"`
-	Handle        string `json:"handle"`
-	CompanyID     string `json:"companyId"`
-	CompanyHandle string `json:"companyHandle"`
-	LogoURL       string `json:"logoUrl"`
-	Name          string `json:"name"`
-	Status        struct {
-		ID    int    `json:"id"`
-		Value string `json:"value"`
-	} `json:"status"`
-	ConfidentialityLevel struct {
-		ID    int    `json:"id"`
-		Value string `json:"value"`
-	} `json:"confidentialityLevel"`
-	WebLinks struct {
+	ID                   string                          `json:"id"`
+	Handle               string                          `json:"handle"`
+	CompanyID            string                          `json:"companyId"`
+	CompanyHandle        string                          `json:"companyHandle"`
+	LogoURL              string                          `json:"logoUrl"`
+	Name                 string                          `json:"name"`
+	Status               EnumValue[ProgramStatus]        `json:"status"`
+	ConfidentialityLevel EnumValue[ConfidentialityLevel] `json:"confidentialityLevel"`
+	WebLinks             struct {
 		Details string `json:"details"`
 	} `json:"webLinks"`
-	Type struct {
-		ID    int    `json:"id"`
-		Value string `json:"value"`
-	} `json:"type"`
+	Type EnumValue[ProgramType] `json:"type"`
 }
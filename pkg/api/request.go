@@ -0,0 +1,58 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+)
+
+// newJSONRequest creates an API request with the given body encoded as JSON
+// the body can be rewound, so the request can be retried
+func newJSONRequest(ctx context.Context, method, uri string, body interface{}) (*http.Request, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL+uri, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("content-type", "application/json")
+
+	return req, nil
+}
+
+// doRequest executes the API request and decodes the JSON response into out, if given
+// non-successful responses are returned as an *APIError
+func (e *Endpoint) doRequest(req *http.Request, out interface{}) error {
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not execute request")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 399 {
+		return newAPIError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "could not read response")
+	}
+
+	if err := json.Unmarshal(b, out); err != nil {
+		return errors.Wrap(err, "could not decode response")
+	}
+
+	return nil
+}
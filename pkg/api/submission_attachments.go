@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	submissionAttachmentsUri = "/company/v2/submissions/%s/attachments"
+	submissionAttachmentUri  = "/company/v2/submissions/%s/attachments/%s"
+)
+
+// GetSubmissionAttachments returns the metadata of all files attached to the submission
+func (e *Endpoint) GetSubmissionAttachments(ctx context.Context, code string) ([]SubmissionAttachment, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+fmt.Sprintf(submissionAttachmentsUri, url.PathEscape(code)), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create get attachments request")
+	}
+
+	var attachments []SubmissionAttachment
+	if err := e.doRequest(req, &attachments); err != nil {
+		return nil, errors.Wrap(err, "could not get attachments")
+	}
+
+	return attachments, nil
+}
+
+// DownloadSubmissionAttachment streams the contents of the attachment to the writer and returns the amount of bytes written
+// the file is never fully buffered in memory, use the context to limit how long the download may take
+func (e *Endpoint) DownloadSubmissionAttachment(ctx context.Context, code, attachmentId string, w io.Writer) (int64, error) {
+	uri := fmt.Sprintf(submissionAttachmentUri, url.PathEscape(code), url.PathEscape(attachmentId))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+uri, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not create download attachment request")
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not download attachment")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 399 {
+		return 0, errors.Wrap(newAPIError(resp), "could not download attachment")
+	}
+
+	written, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return written, errors.Wrap(err, "could not stream attachment")
+	}
+
+	if resp.ContentLength >= 0 && written != resp.ContentLength {
+		return written, errors.Errorf("attachment truncated, got %d out of %d bytes", written, resp.ContentLength)
+	}
+
+	return written, nil
+}
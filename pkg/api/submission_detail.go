@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+)
+
+const (
+	submissionDetailUri = "/company/v2/submissions/%s"
+)
+
+// GetSubmission returns the full report of the submission with the given code
+func (e *Endpoint) GetSubmission(ctx context.Context, code string) (*SubmissionDetail, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+fmt.Sprintf(submissionDetailUri, url.PathEscape(code)), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create get submission request")
+	}
+
+	var submission SubmissionDetail
+	if err := e.doRequest(req, &submission); err != nil {
+		return nil, errors.Wrap(err, "could not get submission")
+	}
+
+	return &submission, nil
+}
+
+type SubmissionDetail struct {
+	Submission
+
+	Report struct {
+		OriginalTitle               string `json:"originalTitle"`
+		Description                 string `json:"description"`
+		Impact                      string `json:"impact"`
+		ProofOfConcept              string `json:"proofOfConcept"`
+		EndpointVulnerableComponent string `json:"endpointVulnerableComponent"`
+		IPAddress                   string `json:"ipAddress"`
+		Domain                      struct {
+			ID   string `json:"id"`
+			Type struct {
+				ID    int    `json:"id"`
+				Value string `json:"value"`
+			} `json:"type"`
+			Value string `json:"value"`
+			Tier  struct {
+				ID    int    `json:"id"`
+				Value string `json:"value"`
+			} `json:"tier"`
+		} `json:"domain"`
+		Type struct {
+			ID       int    `json:"id"`
+			Value    string `json:"value"`
+			Category string `json:"category"`
+			CWE      string `json:"cwe"`
+		} `json:"type"`
+		Attachments []SubmissionAttachment `json:"attachments"`
+	} `json:"report"`
+
+	Collaborators []SubmissionCollaborator `json:"collaborators"`
+	Payouts       []SubmissionPayout       `json:"payouts"`
+}
+
+type SubmissionAttachment struct {
+	ID        string `json:"id"`
+	FileName  string `json:"fileName"`
+	MimeType  string `json:"mimeType"`
+	Size      int64  `json:"size"`
+	CreatedAt int    `json:"createdAt"`
+	// SHA256 is the hex encoded hash of the file contents, if known
+	SHA256 string `json:"sha256"`
+}
+
+type SubmissionCollaborator struct {
+	UserID    string `json:"userId"`
+	UserName  string `json:"userName"`
+	AvatarURL string `json:"avatarUrl"`
+	Role      string `json:"role"`
+	// BountySplit is the percentage of every payout the collaborator receives
+	BountySplit float64 `json:"bountySplitPercentage"`
+}
+
+type SubmissionPayout struct {
+	ID   string `json:"id"`
+	Type struct {
+		ID    PayoutType `json:"id"`
+		Value string     `json:"value"`
+	} `json:"type"`
+	Amount struct {
+		Value    float64 `json:"value"`
+		Currency string  `json:"currency"`
+	} `json:"amount"`
+	Status struct {
+		ID    int    `json:"id"`
+		Value string `json:"value"`
+	} `json:"status"`
+	CreatedAt int `json:"createdAt"`
+	PaidAt    int `json:"paidAt"`
+	// Recipients is how the payout is split between the researcher and collaborators
+	Recipients []PayoutRecipient `json:"recipients"`
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"sort"
+)
+
+const (
+	submissionEventsUri = "/company/v2/submissions/%s/events"
+)
+
+type SubmissionEventType int
+
+const (
+	SubmissionEventStatusChanged   SubmissionEventType = 1
+	SubmissionEventSeverityChanged SubmissionEventType = 2
+	SubmissionEventMessage         SubmissionEventType = 3
+	SubmissionEventNote            SubmissionEventType = 4
+	SubmissionEventAssigned        SubmissionEventType = 5
+	SubmissionEventPayout          SubmissionEventType = 6
+)
+
+// GetSubmissionEvents returns the history of the submission, ordered from oldest to newest
+func (e *Endpoint) GetSubmissionEvents(ctx context.Context, code string) ([]SubmissionEvent, error) {
+	events, err := collect(paginate[SubmissionEvent](ctx, e, fmt.Sprintf(submissionEventsUri, url.PathEscape(code)), nil))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get submission events")
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt < events[j].CreatedAt
+	})
+
+	return events, nil
+}
+
+type SubmissionEvent struct {
+	Type struct {
+		ID    SubmissionEventType `json:"id"`
+		Value string              `json:"value"`
+	} `json:"type"`
+	CreatedAt int `json:"createdAt"`
+	User      struct {
+		UserID    string `json:"userId"`
+		UserName  string `json:"userName"`
+		AvatarURL string `json:"avatarUrl"`
+		Role      string `json:"role"`
+	} `json:"user"`
+
+	// Payload holds the type specific fields of the event
+	// use a type switch on the *StatusChangedEvent, *SeverityChangedEvent, ... types to inspect it
+	Payload SubmissionEventPayload `json:"-"`
+}
+
+type SubmissionEventPayload interface {
+	// Summary returns a human readable description of the event
+	Summary() string
+}
+
+// UnmarshalJSON decodes the event and its payload based on the event type
+func (s *SubmissionEvent) UnmarshalJSON(b []byte) error {
+	// prevent recursing into this function
+	type submissionEvent SubmissionEvent
+
+	var event submissionEvent
+	if err := json.Unmarshal(b, &event); err != nil {
+		return err
+	}
+
+	var payload SubmissionEventPayload
+
+	switch event.Type.ID {
+	case SubmissionEventStatusChanged:
+		payload = &StatusChangedEvent{}
+	case SubmissionEventSeverityChanged:
+		payload = &SeverityChangedEvent{}
+	case SubmissionEventMessage:
+		payload = &MessageEvent{}
+	case SubmissionEventNote:
+		payload = &NoteEvent{}
+	case SubmissionEventAssigned:
+		payload = &AssignedEvent{}
+	case SubmissionEventPayout:
+		payload = &PayoutEvent{}
+	default:
+		payload = &UnknownEvent{Type: event.Type.Value, Raw: append(json.RawMessage{}, b...)}
+	}
+
+	if _, unknown := payload.(*UnknownEvent); !unknown {
+		if err := json.Unmarshal(b, payload); err != nil {
+			return errors.Wrapf(err, "could not decode %s event", event.Type.Value)
+		}
+	}
+
+	*s = SubmissionEvent(event)
+	s.Payload = payload
+
+	return nil
+}
+
+type StatusChangedEvent struct {
+	From        EnumValue[SubmissionStatus] `json:"from"`
+	To          EnumValue[SubmissionStatus] `json:"to"`
+	CloseReason EnumValue[CloseReason]      `json:"closeReason"`
+}
+
+func (e *StatusChangedEvent) Summary() string {
+	if e.CloseReason.ID != 0 {
+		return fmt.Sprintf("status changed from %s to %s (%s)", e.From, e.To, e.CloseReason)
+	}
+
+	return fmt.Sprintf("status changed from %s to %s", e.From, e.To)
+}
+
+type SeverityChangedEvent struct {
+	From SubmissionSeverity `json:"from"`
+	To   SubmissionSeverity `json:"to"`
+}
+
+func (e *SeverityChangedEvent) Summary() string {
+	return fmt.Sprintf("severity changed from %s to %s", e.From.Value, e.To.Value)
+}
+
+type MessageEvent struct {
+	Message string `json:"message"`
+}
+
+func (e *MessageEvent) Summary() string {
+	return "message: " + e.Message
+}
+
+// NoteEvent is an internal note, only visible to the company
+type NoteEvent struct {
+	Note string `json:"note"`
+}
+
+func (e *NoteEvent) Summary() string {
+	return "internal note: " + e.Note
+}
+
+type AssignedEvent struct {
+	Assignee *struct {
+		UserID   string `json:"userId"`
+		UserName string `json:"userName"`
+		Email    string `json:"email"`
+	} `json:"assignee"`
+}
+
+func (e *AssignedEvent) Summary() string {
+	if e.Assignee == nil {
+		return "unassigned"
+	}
+
+	return "assigned to " + e.Assignee.UserName
+}
+
+type PayoutEvent struct {
+	Payout SubmissionPayout `json:"payout"`
+}
+
+func (e *PayoutEvent) Summary() string {
+	return fmt.Sprintf("%s payout of %.2f %s (%s)", e.Payout.Type.Value, e.Payout.Amount.Value,
+		e.Payout.Amount.Currency, e.Payout.Status.Value)
+}
+
+// UnknownEvent is an event type not known to the SDK, the full event is kept as-is
+type UnknownEvent struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (e *UnknownEvent) Summary() string {
+	return e.Type
+}
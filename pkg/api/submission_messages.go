@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	submissionMessagesUri = "/company/v2/submissions/%s/messages"
+	submissionNotesUri    = "/company/v2/submissions/%s/notes"
+	submissionFeedbackUri = "/company/v2/submissions/%s/awaiting-feedback"
+)
+
+type submissionMessageRequest struct {
+	Message string `json:"message"`
+}
+
+type submissionNoteRequest struct {
+	Note string `json:"note"`
+}
+
+// PostSubmissionMessage sends a message to the researcher of the submission
+func (e *Endpoint) PostSubmissionMessage(ctx context.Context, code, message string) error {
+	if strings.TrimSpace(message) == "" {
+		return errors.New("a message is required")
+	}
+
+	if err := e.postSubmission(ctx, submissionMessagesUri, code, submissionMessageRequest{Message: message}); err != nil {
+		return errors.Wrap(err, "could not post message")
+	}
+
+	return nil
+}
+
+// PostSubmissionNote adds an internal note to the submission, only visible to the company
+func (e *Endpoint) PostSubmissionNote(ctx context.Context, code, note string) error {
+	if strings.TrimSpace(note) == "" {
+		return errors.New("a note is required")
+	}
+
+	if err := e.postSubmission(ctx, submissionNotesUri, code, submissionNoteRequest{Note: note}); err != nil {
+		return errors.Wrap(err, "could not post note")
+	}
+
+	return nil
+}
+
+// RequestSubmissionFeedback asks the researcher for more information
+// the submission is marked as awaiting feedback until the researcher responds
+// like posted messages it is never retried automatically, so the researcher is not asked twice
+func (e *Endpoint) RequestSubmissionFeedback(ctx context.Context, code, message string) error {
+	if strings.TrimSpace(message) == "" {
+		return errors.New("a message is required")
+	}
+
+	if err := e.updateSubmission(withoutRetry(ctx), submissionFeedbackUri, code, submissionMessageRequest{Message: message}); err != nil {
+		return errors.Wrap(err, "could not request feedback")
+	}
+
+	return nil
+}
+
+// postSubmission creates a new resource on the submission
+// it is never retried automatically as a retry after e.g. a timeout could post it twice
+func (e *Endpoint) postSubmission(ctx context.Context, uri, code string, body interface{}) error {
+	req, err := newJSONRequest(withoutRetry(ctx), http.MethodPost, fmt.Sprintf(uri, url.PathEscape(code)), body)
+	if err != nil {
+		return errors.Wrap(err, "could not create submission request")
+	}
+
+	return e.doRequest(req, nil)
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	submissionPayoutsUri = "/company/v2/submissions/%s/payouts"
+)
+
+type PayoutType int
+
+const (
+	PayoutTypeBounty PayoutType = 1
+	PayoutTypeBonus  PayoutType = 2
+)
+
+// ParsePayoutType returns the payout type for its name, 'bounty' or 'bonus'
+func ParsePayoutType(name string) (PayoutType, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "bounty":
+		return PayoutTypeBounty, nil
+	case "bonus":
+		return PayoutTypeBonus, nil
+	default:
+		return 0, errors.New("unknown payout type: " + name)
+	}
+}
+
+type PayoutRecipient struct {
+	UserID     string  `json:"userId"`
+	UserName   string  `json:"userName"`
+	Percentage float64 `json:"percentage"`
+	Amount     struct {
+		Value    float64 `json:"value"`
+		Currency string  `json:"currency"`
+	} `json:"amount"`
+}
+
+// GetSubmissionPayouts returns the individual bounty and bonus payouts of the submission
+func (e *Endpoint) GetSubmissionPayouts(ctx context.Context, code string) ([]SubmissionPayout, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+fmt.Sprintf(submissionPayoutsUri, url.PathEscape(code)), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create get payouts request")
+	}
+
+	var payouts []SubmissionPayout
+	if err := e.doRequest(req, &payouts); err != nil {
+		return nil, errors.Wrap(err, "could not get payouts")
+	}
+
+	return payouts, nil
+}
+
+type PayoutRequest struct {
+	Type PayoutType `json:"type"`
+	// Amount is the total amount, split between the researcher and collaborators by the platform
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+	// Message is shown to the researcher
+	Message string `json:"message"`
+}
+
+// CreateSubmissionPayout awards a bounty or bonus for the submission
+// it is never retried automatically as a retry after e.g. a timeout could pay twice, check GetSubmissionPayouts instead
+func (e *Endpoint) CreateSubmissionPayout(ctx context.Context, code string, payout PayoutRequest) (*SubmissionPayout, error) {
+	if payout.Type != PayoutTypeBounty && payout.Type != PayoutTypeBonus {
+		return nil, errors.Errorf("unknown payout type %d", payout.Type)
+	}
+
+	if payout.Amount <= 0 || math.IsInf(payout.Amount, 0) || math.IsNaN(payout.Amount) {
+		return nil, errors.New("payout amount must be positive")
+	}
+
+	if len(payout.Currency) != 3 {
+		return nil, errors.New("payout currency must be an ISO 4217 code such as EUR")
+	}
+
+	payout.Currency = strings.ToUpper(payout.Currency)
+
+	req, err := newJSONRequest(withoutRetry(ctx), http.MethodPost, fmt.Sprintf(submissionPayoutsUri, url.PathEscape(code)), payout)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create payout request")
+	}
+
+	var created SubmissionPayout
+	if err := e.doRequest(req, &created); err != nil {
+		return nil, errors.Wrap(err, "could not create payout")
+	}
+
+	return &created, nil
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	submissionInternalReferenceUri = "/company/v2/submissions/%s/internal-reference"
+	submissionTagsUri              = "/company/v2/submissions/%s/tags"
+)
+
+// InternalReference links a submission to a ticket in your own tracker
+type InternalReference struct {
+	Reference string `json:"reference"`
+	URL       string `json:"url"`
+}
+
+// SetSubmissionInternalReference links the submission to the given internal reference
+func (e *Endpoint) SetSubmissionInternalReference(ctx context.Context, code string, reference InternalReference) error {
+	if strings.TrimSpace(reference.Reference) == "" {
+		return errors.New("a reference is required")
+	}
+
+	if reference.URL != "" {
+		if parsed, err := url.Parse(reference.URL); err != nil || !parsed.IsAbs() {
+			return errors.New("the reference url must be an absolute url")
+		}
+	}
+
+	if err := e.updateSubmission(ctx, submissionInternalReferenceUri, code, reference); err != nil {
+		return errors.Wrap(err, "could not set internal reference")
+	}
+
+	return nil
+}
+
+// ClearSubmissionInternalReference removes the internal reference of the submission
+func (e *Endpoint) ClearSubmissionInternalReference(ctx context.Context, code string) error {
+	uri := fmt.Sprintf(submissionInternalReferenceUri, url.PathEscape(code))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, apiURL+uri, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not create clear internal reference request")
+	}
+
+	if err := e.doRequest(req, nil); err != nil {
+		return errors.Wrap(err, "could not clear internal reference")
+	}
+
+	return nil
+}
+
+type submissionTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// SetSubmissionTags replaces all tags of the submission
+func (e *Endpoint) SetSubmissionTags(ctx context.Context, code string, tags []string) error {
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+
+	if err := e.updateSubmission(ctx, submissionTagsUri, code, submissionTagsRequest{Tags: tags}); err != nil {
+		return errors.Wrap(err, "could not set tags")
+	}
+
+	return nil
+}
+
+// AddSubmissionTags adds the tags to the current tags of the submission
+// the current tags are retrieved first, so concurrent tag changes to the same submission may be lost
+func (e *Endpoint) AddSubmissionTags(ctx context.Context, code string, tags ...string) error {
+	tags = normalizeTags(tags)
+
+	return e.modifySubmissionTags(ctx, code, func(current []string) []string {
+		for _, tag := range tags {
+			if !slices.Contains(current, tag) {
+				current = append(current, tag)
+			}
+		}
+
+		return current
+	})
+}
+
+// RemoveSubmissionTags removes the tags from the current tags of the submission
+// the current tags are retrieved first, so concurrent tag changes to the same submission may be lost
+func (e *Endpoint) RemoveSubmissionTags(ctx context.Context, code string, tags ...string) error {
+	tags = normalizeTags(tags)
+
+	return e.modifySubmissionTags(ctx, code, func(current []string) []string {
+		return slices.DeleteFunc(current, func(tag string) bool {
+			return slices.Contains(tags, tag)
+		})
+	})
+}
+
+// normalizeTags trims the tags and drops empty ones, so adding and removing tags match them the same way
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized
+}
+
+func (e *Endpoint) modifySubmissionTags(ctx context.Context, code string, modify func([]string) []string) error {
+	submission, err := e.GetSubmission(ctx, code)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve current tags")
+	}
+
+	current := slices.Clone(submission.Tags)
+	updated := modify(slices.Clone(current))
+
+	if slices.Equal(current, updated) {
+		e.logger.WithField("code", code).Debug("tags are unchanged, skipping update")
+		return nil
+	}
+
+	return e.SetSubmissionTags(ctx, code, updated)
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestModifySubmissionTags(t *testing.T) {
+	var updated []string
+
+	e := newTestEndpoint(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"code":"CODE","tags":["triaged","needs-retest"]}`))
+			return
+		}
+
+		var body submissionTagsRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		updated = body.Tags
+	}))
+
+	if err := e.RemoveSubmissionTags(context.Background(), "CODE", " needs-retest ", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(updated, []string{"triaged"}) {
+		t.Errorf("unexpected tags after removing %v", updated)
+	}
+
+	if err := e.AddSubmissionTags(context.Background(), "CODE", " sla ", "triaged", "  "); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(updated, []string{"triaged", "needs-retest", "sla"}) {
+		t.Errorf("unexpected tags after adding %v", updated)
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	submissionAcceptUri  = "/company/v2/submissions/%s/accept"
+	submissionCloseUri   = "/company/v2/submissions/%s/close"
+	submissionArchiveUri = "/company/v2/submissions/%s/archive"
+	submissionTriageUri  = "/company/v2/submissions/%s/triage"
+)
+
+type submissionStateRequest struct {
+	Message       string      `json:"message"`
+	CloseReasonID CloseReason `json:"closeReasonId,omitempty"`
+}
+
+// AcceptSubmission accepts the submission, the message is sent to the researcher
+func (e *Endpoint) AcceptSubmission(ctx context.Context, code, message string) error {
+	return e.changeSubmissionState(ctx, submissionAcceptUri, code, submissionStateRequest{Message: message})
+}
+
+// CloseSubmission closes the submission for the given reason, the message is sent to the researcher
+func (e *Endpoint) CloseSubmission(ctx context.Context, code string, reason CloseReason, message string) error {
+	if _, known := closeReasonNames[reason]; !known {
+		return errors.Errorf("unknown close reason %d", reason)
+	}
+
+	return e.changeSubmissionState(ctx, submissionCloseUri, code, submissionStateRequest{Message: message, CloseReasonID: reason})
+}
+
+// ArchiveSubmission archives the submission, the message is sent to the researcher
+func (e *Endpoint) ArchiveSubmission(ctx context.Context, code, message string) error {
+	return e.changeSubmissionState(ctx, submissionArchiveUri, code, submissionStateRequest{Message: message})
+}
+
+// ReopenSubmission moves the submission back to triage, the message is sent to the researcher
+func (e *Endpoint) ReopenSubmission(ctx context.Context, code, message string) error {
+	return e.changeSubmissionState(ctx, submissionTriageUri, code, submissionStateRequest{Message: message})
+}
+
+// changeSubmissionState is never retried automatically as a retry after e.g. a timeout could notify the researcher twice
+func (e *Endpoint) changeSubmissionState(ctx context.Context, uri, code string, body submissionStateRequest) error {
+	if strings.TrimSpace(body.Message) == "" {
+		return errors.New("a message is required to change the submission state")
+	}
+
+	req, err := newJSONRequest(withoutRetry(ctx), http.MethodPut, fmt.Sprintf(uri, url.PathEscape(code)), body)
+	if err != nil {
+		return errors.Wrap(err, "could not create submission state request")
+	}
+
+	if err := e.doRequest(req, nil); err != nil {
+		return errors.Wrap(err, "could not change submission state")
+	}
+
+	return nil
+}
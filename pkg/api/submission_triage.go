@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/hazcod/go-intigriti/pkg/cvss"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	submissionSeverityUri = "/company/v2/submissions/%s/severity"
+	submissionDomainUri   = "/company/v2/submissions/%s/domain"
+	submissionTypeUri     = "/company/v2/submissions/%s/type"
+)
+
+type submissionSeverityRequest struct {
+	SeverityID Severity `json:"severityId,omitempty"`
+	Vector     string   `json:"vector,omitempty"`
+}
+
+// SetSubmissionSeverity changes the severity of the submission
+// when a CVSS vector is given it is validated first and the severity may be left 0 to derive it from the vector
+func (e *Endpoint) SetSubmissionSeverity(ctx context.Context, code string, severity Severity, vector string) error {
+	vector = strings.TrimSpace(vector)
+
+	if vector == "" && severity == 0 {
+		return errors.New("a severity or CVSS vector is required")
+	}
+
+	if _, known := severityNames[severity]; severity != 0 && !known {
+		return errors.Errorf("unknown severity %d", severity)
+	}
+
+	if vector != "" {
+		if _, err := cvss.Parse(vector); err != nil {
+			return errors.Wrap(err, "invalid CVSS vector")
+		}
+	}
+
+	body := submissionSeverityRequest{SeverityID: severity, Vector: vector}
+	if err := e.updateSubmission(ctx, submissionSeverityUri, code, body); err != nil {
+		return errors.Wrap(err, "could not change submission severity")
+	}
+
+	return nil
+}
+
+type submissionDomainRequest struct {
+	DomainID string `json:"domainId"`
+}
+
+// SetSubmissionDomain changes the affected domain of the submission
+// the domain identifier is one of the program domains, see ProgramDetail.Domains
+func (e *Endpoint) SetSubmissionDomain(ctx context.Context, code, domainId string) error {
+	if strings.TrimSpace(domainId) == "" {
+		return errors.New("a domain identifier is required")
+	}
+
+	if err := e.updateSubmission(ctx, submissionDomainUri, code, submissionDomainRequest{DomainID: domainId}); err != nil {
+		return errors.Wrap(err, "could not change submission domain")
+	}
+
+	return nil
+}
+
+type submissionTypeRequest struct {
+	TypeID int `json:"typeId"`
+}
+
+// SetSubmissionVulnerabilityType changes the vulnerability type of the submission
+func (e *Endpoint) SetSubmissionVulnerabilityType(ctx context.Context, code string, typeId int) error {
+	if typeId <= 0 {
+		return errors.New("a vulnerability type identifier is required")
+	}
+
+	if err := e.updateSubmission(ctx, submissionTypeUri, code, submissionTypeRequest{TypeID: typeId}); err != nil {
+		return errors.Wrap(err, "could not change submission vulnerability type")
+	}
+
+	return nil
+}
+
+// updateSubmission sends the JSON body to the given submission resource
+func (e *Endpoint) updateSubmission(ctx context.Context, uri, code string, body interface{}) error {
+	req, err := newJSONRequest(ctx, http.MethodPut, fmt.Sprintf(uri, url.PathEscape(code)), body)
+	if err != nil {
+		return errors.Wrap(err, "could not create submission update request")
+	}
+
+	return e.doRequest(req, nil)
+}
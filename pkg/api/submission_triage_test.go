@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSetSubmissionSeverityVector(t *testing.T) {
+	var requests []submissionSeverityRequest
+
+	e := newTestEndpoint(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body submissionSeverityRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		requests = append(requests, body)
+	}))
+
+	for _, vector := range []string{"CVSS:3.1/AV:N/AC:L", "CVSS:2.0/AV:N", "AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"} {
+		if err := e.SetSubmissionSeverity(context.Background(), "CODE", 0, vector); err == nil {
+			t.Errorf("expected vector '%s' to be rejected", vector)
+		}
+	}
+
+	if len(requests) != 0 {
+		t.Fatalf("invalid vectors were sent: %v", requests)
+	}
+
+	const vector = "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"
+	if err := e.SetSubmissionSeverity(context.Background(), "CODE", 0, " "+vector+" "); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 1 || requests[0].Vector != vector {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
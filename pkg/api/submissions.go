@@ -1,12 +1,12 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"github.com/hazcod/go-intigriti/pkg/cvss"
 	"github.com/pkg/errors"
-	"io"
-	"net/http"
-	"strings"
+	"iter"
+	"net/url"
 )
 
 const (
@@ -16,106 +16,70 @@ const (
 
 // GetProgramSubmissions returns all submissions for the given program identifier
 func (e *Endpoint) GetProgramSubmissions(programId string) ([]Submission, error) {
-	req, err := http.NewRequest(http.MethodGet, apiURL+fmt.Sprintf(programSubmissionUri, programId), nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create get programs")
-	}
-
-	resp, err := e.client.Do(req)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not get programs")
-	}
-
-	if resp.StatusCode > 399 {
-		return nil, errors.Errorf("returned status %d", resp.StatusCode)
-	}
-
-	defer resp.Body.Close()
+	return e.GetProgramSubmissionsWithContext(context.Background(), programId)
+}
 
-	b, err := io.ReadAll(resp.Body)
+// GetProgramSubmissionsWithContext returns all submissions for the given program identifier
+// every page is retrieved, use ProgramSubmissions to iterate over them lazily instead
+func (e *Endpoint) GetProgramSubmissionsWithContext(ctx context.Context, programId string) ([]Submission, error) {
+	submissions, err := collect(e.ProgramSubmissions(ctx, programId, nil))
 	if err != nil {
-		return nil, errors.Wrap(err, "could not read response")
-	}
-
-	var submissions []Submission
-
-	if err := json.Unmarshal(b, &submissions); err != nil {
-		return nil, errors.Wrap(err, "could not decode programs")
+		return nil, errors.Wrap(err, "could not get program submissions")
 	}
 
 	return submissions, nil
 }
 
+// ProgramSubmissions iterates over all submissions for the given program identifier
+// pages are retrieved lazily while iterating, iteration stops after the first error
+func (e *Endpoint) ProgramSubmissions(ctx context.Context, programId string, opts *PageOptions) iter.Seq2[Submission, error] {
+	return paginate[Submission](ctx, e, fmt.Sprintf(programSubmissionUri, url.PathEscape(programId)), opts)
+}
+
 // GetAllSubmissions returns all submissions for all programs
 func (e *Endpoint) GetAllSubmissions() ([]Submission, error) {
-	req, err := http.NewRequest(http.MethodGet, apiURL+submissionUri, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create get submissions request")
-	}
+	return e.GetAllSubmissionsWithContext(context.Background())
+}
 
-	resp, err := e.client.Do(req)
+// GetAllSubmissionsWithContext returns all submissions for all programs
+// every page is retrieved, use Submissions to iterate over them lazily instead
+func (e *Endpoint) GetAllSubmissionsWithContext(ctx context.Context) ([]Submission, error) {
+	submissions, err := collect(e.Submissions(ctx, nil))
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get submissions")
 	}
 
-	if resp.StatusCode > 399 {
-		return nil, errors.Errorf("returned status %d", resp.StatusCode)
-	}
-
-	defer resp.Body.Close()
-
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not read response")
-	}
-
-	var submissions []Submission
-
-	if err := json.Unmarshal(b, &submissions); err != nil {
-		return nil, errors.Wrap(err, "could not decode submissions")
-	}
-
 	return submissions, nil
 }
 
+// Submissions iterates over all submissions for all programs
+// pages are retrieved lazily while iterating, iteration stops after the first error
+func (e *Endpoint) Submissions(ctx context.Context, opts *PageOptions) iter.Seq2[Submission, error] {
+	return paginate[Submission](ctx, e, submissionUri, opts)
+}
+
 type Submission struct {
-	Code              string      `json:"code"`
-	InternalReference interface{} `json:"internalReference"`
-	Title             string      `json:"title"`
-	ProgramID         string      `json:"programId"`
-	Severity          struct {
-		ID     int         `json:"id"`
-		Vector interface{} `json:"vector"`
-		Value  string      `json:"value"`
-	} `json:"severity"`
-	State struct {
-		Status struct {
-			ID    int    `json:"id"`
-			Value string `json:"value"`
-		} `json:"status"`
-		CloseReason struct {
-			ID    int    `json:"id"`
-			Value string `json:"value"`
-		} `json:"closeReason"`
+	Code              string             `json:"code"`
+	InternalReference *InternalReference `json:"internalReference"`
+	Title             string             `json:"title"`
+	ProgramID         string             `json:"programId"`
+	Severity          SubmissionSeverity `json:"severity"`
+	State             struct {
+		Status      EnumValue[SubmissionStatus] `json:"status"`
+		CloseReason EnumValue[CloseReason]      `json:"closeReason"`
 	} `json:"state"`
 	TotalPayout struct {
 		Value    float64 `json:"value"`
 		Currency string  `json:"currency"`
 	} `json:"totalPayout"`
-	CreatedAt        int  `json:"createdAt"`
-	LastUpdatedAt    int  `json:"lastUpdatedAt"`
-	AwaitingFeedback bool `json:"awaitingFeedback"`
-	Destroyed        bool `json:"destroyed"`
-	Assignee         struct {
-		AvatarURL string `json:"avatarUrl"`
-		Email     string `json:"email"`
-		Role      string `json:"role"`
-		UserID    string `json:"userId"`
-		Username  string `json:"userName"`
-	} `json:"assignee"`
-	Tags      []interface{} `json:"tags"`
-	GroupID   interface{}   `json:"groupId"`
-	Submitter struct {
+	CreatedAt        int         `json:"createdAt"`
+	LastUpdatedAt    int         `json:"lastUpdatedAt"`
+	AwaitingFeedback bool        `json:"awaitingFeedback"`
+	Destroyed        bool        `json:"destroyed"`
+	Assignee         CompanyUser `json:"assignee"`
+	Tags             []string    `json:"tags"`
+	GroupID          interface{} `json:"groupId"`
+	Submitter        struct {
 		Ranking struct {
 			Rank       int         `json:"rank"`
 			Reputation int         `json:"reputation"`
@@ -133,19 +97,26 @@ type Submission struct {
 	} `json:"webLinks"`
 }
 
+// IsClosed returns whether the submission was closed, for any close reason
 func (s *Submission) IsClosed() bool {
-	return s.State.CloseReason.Value != ""
+	return s.State.Status.ID == SubmissionStatusClosed || s.State.CloseReason.ID != 0
+}
+
+// CVSS returns the parsed CVSS vector the submission was scored with, or nil when it was not scored with one
+func (s *Submission) CVSS() (*cvss.Vector, error) {
+	return s.Severity.CVSS()
 }
 
+// IsActive returns whether the submission is in none of the triage, closed, accepted or archived states
 func (s *Submission) IsActive() bool {
-	switch strings.ToLower(s.State.Status.Value) {
-	case "triage":
+	switch s.State.Status.ID {
+	case SubmissionStatusTriage:
 		return false
-	case "closed":
+	case SubmissionStatusClosed:
 		return false
-	case "accepted":
+	case SubmissionStatusAccepted:
 		return false
-	case "archived":
+	case SubmissionStatusArchived:
 		return false
 	default:
 		return true
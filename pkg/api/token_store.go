@@ -0,0 +1,58 @@
+package api
+
+import (
+	"github.com/hazcod/go-intigriti/pkg/config"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+// persistingTokenSource saves every token of the underlying source to the token store
+// failing to save is logged, the token can still be used for this run
+type persistingTokenSource struct {
+	Source oauth2.TokenSource
+	Store  config.TokenStore
+	Logger *logrus.Logger
+}
+
+func (s persistingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.Source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	saveToken(s.Logger, s.Store, token)
+
+	return token, nil
+}
+
+// saveToken saves the token to the store, if any
+func saveToken(logger *logrus.Logger, store config.TokenStore, token *oauth2.Token) {
+	if store == nil {
+		return
+	}
+
+	if err := store.Save(toCachedToken(token)); err != nil {
+		logger.WithError(err).Warn("could not save token")
+		return
+	}
+
+	logger.Debug("saved token")
+}
+
+func toCachedToken(token *oauth2.Token) *config.CachedToken {
+	return &config.CachedToken{
+		RefreshToken: token.RefreshToken,
+		AccessToken:  token.AccessToken,
+		ExpiryDate:   token.Expiry,
+		Type:         token.TokenType,
+	}
+}
+
+func fromCachedToken(token *config.CachedToken) *oauth2.Token {
+	return &oauth2.Token{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.ExpiryDate,
+		TokenType:    token.Type,
+	}
+}
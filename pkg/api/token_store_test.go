@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hazcod/go-intigriti/pkg/config"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+func TestRefreshSavesToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("refresh_token") != "old-refresh" {
+			t.Errorf("unexpected token request %v: %v", r.PostForm, err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"new-access","refresh_token":"new-refresh","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	previousURL := tokenURL
+	tokenURL = srv.URL
+	defer func() { tokenURL = previousURL }()
+
+	store := config.NewMemoryTokenStore(nil)
+	e := &Endpoint{
+		logger:     logrus.New(),
+		callback:   newCallbackConfig(config.CallbackConfig{}),
+		tokenStore: store,
+		token: &tokenHolder{token: &oauth2.Token{
+			AccessToken:  "old-access",
+			RefreshToken: "old-refresh",
+			Expiry:       time.Now().Add(-time.Hour),
+		}},
+	}
+
+	token, err := e.GetTokenWithContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := store.Load()
+	if err != nil || saved == nil {
+		t.Fatalf("expected the refreshed token to be saved, got %v: %v", saved, err)
+	}
+
+	if token.AccessToken != "new-access" || saved.AccessToken != "new-access" || saved.RefreshToken != "new-refresh" || !saved.ExpiryDate.After(time.Now()) {
+		t.Errorf("unexpected refreshed token %+v, saved %+v", token, saved)
+	}
+}
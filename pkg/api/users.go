@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	usersUri              = "/company/v2/users"
+	submissionAssigneeUri = "/company/v2/submissions/%s/assignee"
+)
+
+type CompanyUser struct {
+	AvatarURL string `json:"avatarUrl"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	UserID    string `json:"userId"`
+	Username  string `json:"userName"`
+}
+
+// GetCompanyUsers returns the users of the company which can be assigned to submissions
+func (e *Endpoint) GetCompanyUsers(ctx context.Context) ([]CompanyUser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+usersUri, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create get users request")
+	}
+
+	var users []CompanyUser
+	if err := e.doRequest(req, &users); err != nil {
+		return nil, errors.Wrap(err, "could not get users")
+	}
+
+	return users, nil
+}
+
+// FindCompanyUser returns the company user with the given identifier, username or email address
+func FindCompanyUser(users []CompanyUser, user string) (*CompanyUser, error) {
+	for i := range users {
+		if users[i].UserID == user || strings.EqualFold(users[i].Username, user) || strings.EqualFold(users[i].Email, user) {
+			return &users[i], nil
+		}
+	}
+
+	return nil, errors.New("unknown company user: " + user)
+}
+
+type submissionAssigneeRequest struct {
+	AssigneeID string `json:"assigneeId"`
+}
+
+// AssignSubmission assigns the submission to the company user with the given identifier
+func (e *Endpoint) AssignSubmission(ctx context.Context, code, userId string) error {
+	if strings.TrimSpace(userId) == "" {
+		return errors.New("a user identifier is required")
+	}
+
+	if err := e.updateSubmission(ctx, submissionAssigneeUri, code, submissionAssigneeRequest{AssigneeID: userId}); err != nil {
+		return errors.Wrap(err, "could not assign submission")
+	}
+
+	return nil
+}
+
+// UnassignSubmission clears the assignee of the submission
+func (e *Endpoint) UnassignSubmission(ctx context.Context, code string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, apiURL+fmt.Sprintf(submissionAssigneeUri, url.PathEscape(code)), nil)
+	if err != nil {
+		return errors.Wrap(err, "could not create unassign request")
+	}
+
+	if err := e.doRequest(req, nil); err != nil {
+		return errors.Wrap(err, "could not unassign submission")
+	}
+
+	return nil
+}
@@ -1,17 +1,23 @@
 package api
 
 import (
-	"math/rand"
+	"crypto/rand"
+	"github.com/pkg/errors"
 )
 
-// randomString generates a random alphanumeric string at the given length
-func randomString(n int) string {
-	var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+// randomString generates a cryptographically random string at the given length
+// every character is one of 64 URL safe characters, so it holds 6 bits of entropy
+func randomString(n int) (string, error) {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_"
 
-	s := make([]rune, n)
-	for i := range s {
-		s[i] = letters[rand.Intn(len(letters))]
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "could not generate random string")
 	}
 
-	return string(s)
+	for i := range b {
+		b[i] = letters[b[i]&63]
+	}
+
+	return string(b), nil
 }
@@ -1,21 +1,126 @@
 package config
 
 import (
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"io"
+	"strings"
 	"time"
 )
 
 type CachedToken struct {
-	RefreshToken string
-	AccessToken  string
-	ExpiryDate   time.Time
-	Type         string
+	RefreshToken string    `json:"refresh_token"`
+	AccessToken  string    `json:"access_token"`
+	ExpiryDate   time.Time `json:"expiry"`
+	Type         string    `json:"type"`
+}
+
+// AuthMode selects how the SDK obtains its access tokens
+type AuthMode string
+
+const (
+	// AuthModeAuthorizationCode uses the cached token and otherwise lets the user log in interactively (default)
+	AuthModeAuthorizationCode AuthMode = "authorization_code"
+	// AuthModeClientCredentials obtains tokens with the client credentials alone, for CI and services
+	AuthModeClientCredentials AuthMode = "client_credentials"
+	// AuthModeStaticToken uses a pre-issued access token as is, it is never refreshed
+	AuthModeStaticToken AuthMode = "static_token"
+)
+
+// Interactive returns whether the mode may need the user to log in through the browser
+func (m AuthMode) Interactive() bool {
+	return m == "" || m == AuthModeAuthorizationCode
+}
+
+// ParseAuthMode returns the auth mode for its name, an empty name being the authorization code mode
+func ParseAuthMode(name string) (AuthMode, error) {
+	switch mode := AuthMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case "":
+		return AuthModeAuthorizationCode, nil
+	case AuthModeAuthorizationCode, AuthModeClientCredentials, AuthModeStaticToken:
+		return mode, nil
+	default:
+		return "", errors.Errorf("unknown auth mode '%s', use %s, %s or %s",
+			name, AuthModeAuthorizationCode, AuthModeClientCredentials, AuthModeStaticToken)
+	}
+}
+
+// LoginMode selects how the user completes the interactive login of the authorization code mode
+type LoginMode string
+
+const (
+	// LoginModeAuto uses the headless login when no display is available, e.g. over SSH or in a container (default)
+	LoginModeAuto LoginMode = "auto"
+	// LoginModeBrowser waits for the browser to be redirected to the local callback listener
+	LoginModeBrowser LoginMode = "browser"
+	// LoginModeHeadless asks to paste the URL the browser was redirected to on the login input
+	LoginModeHeadless LoginMode = "headless"
+)
+
+// ParseLoginMode returns the login mode for its name, an empty name being the auto mode
+func ParseLoginMode(name string) (LoginMode, error) {
+	switch mode := LoginMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case "":
+		return LoginModeAuto, nil
+	case LoginModeAuto, LoginModeBrowser, LoginModeHeadless:
+		return mode, nil
+	default:
+		return "", errors.Errorf("unknown login mode '%s', use %s, %s or %s",
+			name, LoginModeAuto, LoginModeBrowser, LoginModeHeadless)
+	}
 }
 
 type InteractiveAuthenticator interface {
 	OpenURL(url string) error
 }
 
+type CallbackConfig struct {
+	// Host is where the local login callback listener binds to and the redirect URI points at (default localhost)
+	Host string
+
+	// Ports are tried in order until one is free (default 1337)
+	// every one of them has to be registered as redirect URI of your integration
+	Ports []int
+
+	// Path of the redirect URI (default /)
+	Path string
+
+	// AllowEphemeralPort falls back to a random free port when none of the ports are
+	// this only works when the authorization server accepts any loopback port, see RFC 8252 section 7.3
+	AllowEphemeralPort bool
+
+	// SuccessPage is the HTML shown in the browser after logging in, defaults to a page which closes itself
+	SuccessPage string
+}
+
+type RetryConfig struct {
+	// MaxRetries is the amount of times a transient failure is retried
+	// defaults to 3, a negative value disables retries
+	MaxRetries int
+
+	// MinBackoff is the wait before the first retry, doubled for every next retry (default 500ms)
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum wait between retries (default 30s)
+	// a Retry-After response asking to wait longer is returned to the caller instead
+	MaxBackoff time.Duration
+
+	// RetryUnsafeMethods retries non-idempotent requests such as POST too
+	// creating payouts, posting messages and changing the submission state is never retried, as they could be made twice
+	RetryUnsafeMethods bool
+}
+
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained rate of requests (default 10), a negative value disables it
+	RequestsPerSecond float64
+
+	// Burst is the amount of requests which may be sent at once (default the requests per second)
+	Burst int
+
+	// MaxInFlight is the maximum of concurrent requests (default 10), a negative value disables it
+	MaxInFlight int
+}
+
 type Config struct {
 	// Required: API authentication credentials
 	Credentials struct {
@@ -23,6 +128,22 @@ type Config struct {
 		ClientSecret string
 	}
 
+	// Optional: how access tokens are obtained, defaults to the interactive authorization code flow
+	// the client credentials and static token modes never open a listener or browser and fail instead
+	AuthMode AuthMode
+
+	// Optional: the pre-issued access token, required for the static token auth mode
+	StaticToken string
+
+	// Optional: how the user completes an interactive login, defaults to LoginModeAuto
+	LoginMode LoginMode
+
+	// Optional: where the headless login reads the pasted redirect URL from, defaults to stdin
+	LoginInput io.Reader
+
+	// Optional: the redirect URI and local listener of the interactive login, defaults to http://localhost:1337/
+	Callback CallbackConfig
+
 	// Optional: open a browser to complete authentication if user interaction is required
 	OpenBrowser   bool
 	Authenticator InteractiveAuthenticator
@@ -30,6 +151,10 @@ type Config struct {
 	// Optional: token cache if caching previous credentials
 	TokenCache *CachedToken
 
+	// Optional: where the token is loaded from and every new or refreshed token is saved to
+	// the stored token takes precedence over the TokenCache
+	TokenStore TokenStore
+
 	// Optional: logger instance
 	Logger *logrus.Logger
 
@@ -37,4 +162,11 @@ type Config struct {
 	// limit this as much as possible to limit token leakage impact
 	// https://intigriti.readme.io/reference/api-token-scopes
 	APIScopes []string
+
+	// Optional: how transient API failures such as 429 and 502 responses are retried
+	Retry RetryConfig
+
+	// Optional: the request budget shared by all goroutines using the SDK
+	// rate limit headers returned by the API pause requests until the limit resets
+	RateLimit RateLimitConfig
 }
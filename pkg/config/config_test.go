@@ -0,0 +1,22 @@
+package config
+
+import "testing"
+
+func TestParseLoginMode(t *testing.T) {
+	tests := map[string]LoginMode{
+		"":           LoginModeAuto,
+		" Headless ": LoginModeHeadless,
+		"BROWSER":    LoginModeBrowser,
+		"auto":       LoginModeAuto,
+	}
+
+	for name, expected := range tests {
+		if mode, err := ParseLoginMode(name); err != nil || mode != expected {
+			t.Errorf("unexpected login mode '%s' for '%s': %v", mode, name, err)
+		}
+	}
+
+	if _, err := ParseLoginMode("paste"); err == nil {
+		t.Error("expected an unknown login mode to be rejected")
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TokenStore keeps the token between runs, every new or refreshed token is saved to it
+// implementations must be safe for concurrent use
+type TokenStore interface {
+	// Load returns the stored token, or nil when none was stored yet
+	Load() (*CachedToken, error)
+	// Save replaces the stored token
+	Save(token *CachedToken) error
+}
+
+// MemoryTokenStore keeps the token in memory, e.g. to share it between SDK instances of one process
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *CachedToken
+}
+
+// NewMemoryTokenStore creates a memory token store holding the initial token, which may be nil
+func NewMemoryTokenStore(initial *CachedToken) *MemoryTokenStore {
+	return &MemoryTokenStore{token: initial}
+}
+
+func (s *MemoryTokenStore) Load() (*CachedToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		return nil, nil
+	}
+
+	token := *s.token
+	return &token, nil
+}
+
+func (s *MemoryTokenStore) Save(token *CachedToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *token
+	s.token = &saved
+
+	return nil
+}
+
+// FileTokenStore keeps the token as JSON in a file only readable by the current user
+type FileTokenStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileTokenStore creates a file token store, the file is created on the first save
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (s *FileTokenStore) Load() (*CachedToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read token file")
+	}
+
+	var token CachedToken
+	if err := json.Unmarshal(b, &token); err != nil {
+		return nil, errors.Wrap(err, "could not parse token file")
+	}
+
+	return &token, nil
+}
+
+// Save writes the token to a temporary file first, so a crash never leaves a partially written token behind
+func (s *FileTokenStore) Save(token *CachedToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal(token)
+	if err != nil {
+		return errors.Wrap(err, "could not serialize token")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "could not create token file")
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "could not write token file")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "could not write token file")
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return errors.Wrap(err, "could not replace token file")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewFileTokenStore(path)
+
+	if token, err := store.Load(); err != nil || token != nil {
+		t.Fatalf("expected no token before saving, got %v and %v", token, err)
+	}
+
+	saved := &CachedToken{AccessToken: "access", RefreshToken: "refresh", ExpiryDate: time.Now().Add(time.Hour).UTC(), Type: "Bearer"}
+	if err := store.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.AccessToken != saved.AccessToken || loaded.RefreshToken != saved.RefreshToken || !loaded.ExpiryDate.Equal(saved.ExpiryDate) {
+		t.Errorf("expected %+v, got %+v", saved, loaded)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected token file to only be readable by the owner, got %o", perm)
+	}
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	store := NewMemoryTokenStore(&CachedToken{AccessToken: "initial"})
+
+	loaded, err := store.Load()
+	if err != nil || loaded.AccessToken != "initial" {
+		t.Fatalf("unexpected initial token %+v: %v", loaded, err)
+	}
+
+	// the loaded token is a copy, changing it does not change the stored token
+	loaded.AccessToken = "changed"
+
+	if loaded, _ := store.Load(); loaded.AccessToken != "initial" {
+		t.Errorf("stored token was changed to %+v", loaded)
+	}
+
+	if err := store.Save(&CachedToken{AccessToken: "saved", RefreshToken: "refresh"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded, _ := store.Load(); loaded.AccessToken != "saved" || loaded.RefreshToken != "refresh" {
+		t.Errorf("unexpected saved token %+v", loaded)
+	}
+}
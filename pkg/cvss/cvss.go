@@ -0,0 +1,168 @@
+// Package cvss parses CVSS 3.0, 3.1 and 4.0 vectors and computes their base score and qualitative rating.
+package cvss
+
+import (
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// Version is the CVSS specification version a vector was written for
+type Version string
+
+const (
+	Version30 Version = "3.0"
+	Version31 Version = "3.1"
+	Version40 Version = "4.0"
+)
+
+// Vector is a parsed CVSS vector such as CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H
+type Vector struct {
+	version Version
+	metrics map[string]string
+}
+
+// Metric is a metric set in a vector together with the names the specification gives it
+type Metric struct {
+	// the abbreviation used in the vector, e.g. AV
+	Key string
+	// e.g. Attack Vector
+	Name string
+	// the abbreviated value used in the vector, e.g. N
+	Value string
+	// e.g. Network
+	ValueName string
+}
+
+func (m Metric) String() string {
+	return m.Name + ": " + m.ValueName
+}
+
+// Parse parses a CVSS 3.0, 3.1 or 4.0 vector
+// every base metric must be set, the threat, temporal, environmental and supplemental metrics are optional
+func Parse(vector string) (*Vector, error) {
+	parts := strings.Split(strings.TrimSpace(vector), "/")
+
+	v := Vector{metrics: make(map[string]string, len(parts))}
+
+	switch parts[0] {
+	case "CVSS:3.0":
+		v.version = Version30
+	case "CVSS:3.1":
+		v.version = Version31
+	case "CVSS:4.0":
+		v.version = Version40
+	default:
+		return nil, errors.New("vector must start with CVSS:3.0, CVSS:3.1 or CVSS:4.0")
+	}
+
+	for _, part := range parts[1:] {
+		key, value, found := strings.Cut(part, ":")
+		if !found || key == "" || value == "" {
+			return nil, errors.Errorf("invalid metric '%s'", part)
+		}
+
+		if _, seen := v.metrics[key]; seen {
+			return nil, errors.Errorf("duplicate metric '%s'", key)
+		}
+
+		definition, known := v.definition(key)
+		if !known {
+			return nil, errors.Errorf("unknown metric '%s' for CVSS %s", key, v.version)
+		}
+
+		if _, allowed := definition.value(value); !allowed {
+			return nil, errors.Errorf("invalid value '%s' for metric '%s'", value, key)
+		}
+
+		v.metrics[key] = value
+	}
+
+	for _, definition := range v.definitions() {
+		if _, set := v.metrics[definition.Key]; definition.Base && !set {
+			return nil, errors.Errorf("missing base metric '%s'", definition.Key)
+		}
+	}
+
+	return &v, nil
+}
+
+// Version returns the CVSS version of the vector
+func (v *Vector) Version() Version {
+	return v.version
+}
+
+// Get returns the abbreviated value of the metric, e.g. N for AV, or an empty string if it is not set
+func (v *Vector) Get(key string) string {
+	return v.metrics[key]
+}
+
+// Metric returns the metric with its explanation, or false if it is not set in the vector
+func (v *Vector) Metric(key string) (Metric, bool) {
+	value, set := v.metrics[key]
+	if !set {
+		return Metric{}, false
+	}
+
+	definition, _ := v.definition(key)
+	named, _ := definition.value(value)
+
+	return Metric{Key: key, Name: definition.Name, Value: value, ValueName: named.Name}, true
+}
+
+// Metrics returns every metric set in the vector with its explanation, in specification order
+func (v *Vector) Metrics() []Metric {
+	metrics := make([]Metric, 0, len(v.metrics))
+
+	for _, definition := range v.definitions() {
+		if metric, set := v.Metric(definition.Key); set {
+			metrics = append(metrics, metric)
+		}
+	}
+
+	return metrics
+}
+
+// String returns the vector in its canonical form, with the metrics in specification order
+func (v *Vector) String() string {
+	var b strings.Builder
+	b.WriteString("CVSS:" + string(v.version))
+
+	for _, metric := range v.Metrics() {
+		b.WriteString("/" + metric.Key + ":" + metric.Value)
+	}
+
+	return b.String()
+}
+
+// BaseScore returns the base score of the vector between 0.0 and 10.0
+// for CVSS 4.0 this is the CVSS-B score, the threat and environmental metrics are not taken into account
+func (v *Vector) BaseScore() float64 {
+	if v.version == Version40 {
+		return v.baseScore4()
+	}
+
+	return v.baseScore3()
+}
+
+// Rating returns the qualitative severity rating of the base score
+func (v *Vector) Rating() Rating {
+	return RatingOf(v.BaseScore())
+}
+
+func (v *Vector) definitions() []metricDefinition {
+	if v.version == Version40 {
+		return cvss4Metrics
+	}
+
+	return cvss3Metrics
+}
+
+func (v *Vector) definition(key string) (metricDefinition, bool) {
+	for _, definition := range v.definitions() {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+
+	return metricDefinition{}, false
+}
@@ -0,0 +1,60 @@
+package cvss
+
+import "math"
+
+var (
+	cvss3AttackVector = map[string]float64{"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2}
+	cvss3Complexity   = map[string]float64{"L": 0.77, "H": 0.44}
+	cvss3Interaction  = map[string]float64{"N": 0.85, "R": 0.62}
+	cvss3Impact       = map[string]float64{"H": 0.56, "L": 0.22, "N": 0}
+	// privileges weigh heavier when the scope is changed
+	cvss3Privileges        = map[string]float64{"N": 0.85, "L": 0.62, "H": 0.27}
+	cvss3PrivilegesChanged = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}
+)
+
+// baseScore3 implements the base score formula of the CVSS 3.0 and 3.1 specifications
+func (v *Vector) baseScore3() float64 {
+	changed := v.metrics["S"] == "C"
+
+	impactSubScore := 1 - (1-cvss3Impact[v.metrics["C"]])*(1-cvss3Impact[v.metrics["I"]])*(1-cvss3Impact[v.metrics["A"]])
+
+	var impact float64
+	if changed {
+		impact = 7.52*(impactSubScore-0.029) - 3.25*math.Pow(impactSubScore-0.02, 15)
+	} else {
+		impact = 6.42 * impactSubScore
+	}
+
+	if impact <= 0 {
+		return 0
+	}
+
+	privileges := cvss3Privileges
+	if changed {
+		privileges = cvss3PrivilegesChanged
+	}
+
+	exploitability := 8.22 * cvss3AttackVector[v.metrics["AV"]] * cvss3Complexity[v.metrics["AC"]] *
+		privileges[v.metrics["PR"]] * cvss3Interaction[v.metrics["UI"]]
+
+	if changed {
+		return v.roundUp(math.Min(1.08*(impact+exploitability), 10))
+	}
+
+	return v.roundUp(math.Min(impact+exploitability, 10))
+}
+
+// roundUp returns the smallest number with one decimal that is equal to or higher than the score
+// CVSS 3.1 works on integers to avoid floating point errors such as 4.000000001 being rounded up to 4.1
+func (v *Vector) roundUp(score float64) float64 {
+	if v.version == Version30 {
+		return math.Ceil(score*10) / 10
+	}
+
+	scaled := int64(math.Round(score * 100000))
+	if scaled%10000 == 0 {
+		return float64(scaled) / 100000
+	}
+
+	return float64(scaled/10000+1) / 10
+}
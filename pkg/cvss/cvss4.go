@@ -0,0 +1,242 @@
+package cvss
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	// the severity level of every metric value used in scoring, 0 being the most severe
+	cvss4ImpactLevels     = map[string]int{"H": 0, "L": 1, "N": 2}
+	cvss4SubsequentLevels = map[string]int{"S": 0, "H": 1, "L": 2, "N": 3}
+	cvss4RequirementLevel = map[string]int{"H": 0, "M": 1, "L": 2}
+	cvss4Levels           = map[string]map[string]int{
+		"AV": {"N": 0, "A": 1, "L": 2, "P": 3},
+		"PR": {"N": 0, "L": 1, "H": 2},
+		"UI": {"N": 0, "P": 1, "A": 2},
+		"AC": {"L": 0, "H": 1},
+		"AT": {"N": 0, "P": 1},
+		"VC": cvss4ImpactLevels, "VI": cvss4ImpactLevels, "VA": cvss4ImpactLevels,
+		"SC": cvss4SubsequentLevels, "SI": cvss4SubsequentLevels, "SA": cvss4SubsequentLevels,
+		"CR": cvss4RequirementLevel, "IR": cvss4RequirementLevel, "AR": cvss4RequirementLevel,
+	}
+
+	// the highest severity vectors of every EQ level, see tables 24 to 30 of the specification
+	cvss4MaxEQ1 = [][]string{
+		{"AV:N/PR:N/UI:N"},
+		{"AV:A/PR:N/UI:N", "AV:N/PR:L/UI:N", "AV:N/PR:N/UI:P"},
+		{"AV:P/PR:N/UI:N", "AV:A/PR:L/UI:P"},
+	}
+	cvss4MaxEQ2 = [][]string{
+		{"AC:L/AT:N"},
+		{"AC:H/AT:N", "AC:L/AT:P"},
+	}
+	cvss4MaxEQ3EQ6 = [][][]string{
+		{
+			{"VC:H/VI:H/VA:H/CR:H/IR:H/AR:H"},
+			{"VC:H/VI:H/VA:L/CR:M/IR:M/AR:H", "VC:H/VI:H/VA:H/CR:M/IR:M/AR:M"},
+		},
+		{
+			{"VC:L/VI:H/VA:H/CR:H/IR:H/AR:H", "VC:H/VI:L/VA:H/CR:H/IR:H/AR:H"},
+			{
+				"VC:L/VI:H/VA:L/CR:H/IR:M/AR:H", "VC:L/VI:H/VA:H/CR:H/IR:M/AR:M", "VC:H/VI:L/VA:H/CR:M/IR:H/AR:M",
+				"VC:H/VI:L/VA:L/CR:M/IR:H/AR:H", "VC:L/VI:L/VA:H/CR:H/IR:H/AR:M",
+			},
+		},
+		{
+			nil,
+			{"VC:L/VI:L/VA:L/CR:H/IR:H/AR:H"},
+		},
+	}
+	cvss4MaxEQ4 = [][]string{
+		{"SC:H/SI:S/SA:S"},
+		{"SC:H/SI:H/SA:H"},
+		{"SC:L/SI:L/SA:L"},
+	}
+
+	// the number of severity levels within every EQ level, used to scale the distance to the highest severity vector
+	cvss4DepthEQ1    = []int{1, 4, 5}
+	cvss4DepthEQ2    = []int{1, 2}
+	cvss4DepthEQ3EQ6 = [][]int{{7, 6}, {8, 8}, {0, 10}}
+	cvss4DepthEQ4    = []int{6, 5, 4}
+)
+
+// macroVector holds the EQ1 to EQ6 levels a CVSS 4.0 vector is grouped into
+type macroVector [6]int
+
+func (mv macroVector) score() (float64, bool) {
+	score, found := macroVectorScores[fmt.Sprintf("%d%d%d%d%d%d", mv[0], mv[1], mv[2], mv[3], mv[4], mv[5])]
+	return score, found
+}
+
+// lower returns the macro vector with the given EQ one level less severe
+func (mv macroVector) lower(eq int) macroVector {
+	mv[eq]++
+	return mv
+}
+
+// baseMetric4 returns the value of a metric used for the base score, leaving out threat and environmental metrics
+func (v *Vector) baseMetric4(key string) string {
+	switch key {
+	case "E":
+		return "A"
+	case "CR", "IR", "AR":
+		return "H"
+	default:
+		return v.metrics[key]
+	}
+}
+
+func (v *Vector) macroVector4() macroVector {
+	m := v.baseMetric4
+
+	var mv macroVector
+
+	switch {
+	case m("AV") == "N" && m("PR") == "N" && m("UI") == "N":
+		mv[0] = 0
+	case (m("AV") == "N" || m("PR") == "N" || m("UI") == "N") && m("AV") != "P":
+		mv[0] = 1
+	default:
+		mv[0] = 2
+	}
+
+	if m("AC") != "L" || m("AT") != "N" {
+		mv[1] = 1
+	}
+
+	switch {
+	case m("VC") == "H" && m("VI") == "H":
+		mv[2] = 0
+	case m("VC") == "H" || m("VI") == "H" || m("VA") == "H":
+		mv[2] = 1
+	default:
+		mv[2] = 2
+	}
+
+	switch {
+	case m("SI") == "S" || m("SA") == "S":
+		mv[3] = 0
+	case m("SC") == "H" || m("SI") == "H" || m("SA") == "H":
+		mv[3] = 1
+	default:
+		mv[3] = 2
+	}
+
+	switch m("E") {
+	case "P":
+		mv[4] = 1
+	case "U":
+		mv[4] = 2
+	}
+
+	if !(m("CR") == "H" && m("VC") == "H") && !(m("IR") == "H" && m("VI") == "H") && !(m("AR") == "H" && m("VA") == "H") {
+		mv[5] = 1
+	}
+
+	return mv
+}
+
+// maxSeverityDistances returns the severity distance of every metric to the first highest severity vector
+// of the macro vector which is at least as severe as this vector in every metric
+func (v *Vector) maxSeverityDistances(mv macroVector) map[string]int {
+	for _, eq1 := range cvss4MaxEQ1[mv[0]] {
+		for _, eq2 := range cvss4MaxEQ2[mv[1]] {
+			for _, eq3eq6 := range cvss4MaxEQ3EQ6[mv[2]][mv[5]] {
+				for _, eq4 := range cvss4MaxEQ4[mv[3]] {
+					distances := make(map[string]int, len(cvss4Levels))
+					valid := true
+
+					for _, part := range strings.Split(strings.Join([]string{eq1, eq2, eq3eq6, eq4}, "/"), "/") {
+						key, maxValue, _ := strings.Cut(part, ":")
+
+						distances[key] = cvss4Levels[key][v.baseMetric4(key)] - cvss4Levels[key][maxValue]
+						if distances[key] < 0 {
+							valid = false
+							break
+						}
+					}
+
+					if valid {
+						return distances
+					}
+				}
+			}
+		}
+	}
+
+	return map[string]int{}
+}
+
+// baseScore4 implements the CVSS 4.0 scoring algorithm of the FIRST reference implementation
+// the score of the macro vector is lowered by the mean of how far the vector is from its highest severity vector,
+// relative to the score difference with the next lower macro vector of every EQ
+func (v *Vector) baseScore4() float64 {
+	noImpact := true
+	for _, key := range []string{"VC", "VI", "VA", "SC", "SI", "SA"} {
+		noImpact = noImpact && v.baseMetric4(key) == "N"
+	}
+
+	if noImpact {
+		return 0
+	}
+
+	mv := v.macroVector4()
+	score, _ := mv.score()
+	distances := v.maxSeverityDistances(mv)
+
+	var total float64
+	var lowerCount int
+
+	// addLower adds the proportional score difference towards the next lower macro vector, if there is one
+	addLower := func(lowerScore float64, found bool, keys []string, depth int) {
+		if !found {
+			return
+		}
+
+		lowerCount++
+
+		distance := 0
+		for _, key := range keys {
+			distance += distances[key]
+		}
+
+		if depth > 0 {
+			total += (score - lowerScore) * float64(distance) / float64(depth)
+		}
+	}
+
+	lowerScore, found := mv.lower(0).score()
+	addLower(lowerScore, found, []string{"AV", "PR", "UI"}, cvss4DepthEQ1[mv[0]])
+
+	lowerScore, found = mv.lower(1).score()
+	addLower(lowerScore, found, []string{"AC", "AT"}, cvss4DepthEQ2[mv[1]])
+
+	// EQ3 and EQ6 are scored together as not every combination of their levels exists
+	switch {
+	case mv[2] == 0 && mv[5] == 0:
+		lowerScore, found = mv.lower(2).score()
+		if other, otherFound := mv.lower(5).score(); otherFound && (!found || other > lowerScore) {
+			lowerScore, found = other, true
+		}
+	case mv[2] == 1 && mv[5] == 0:
+		lowerScore, found = mv.lower(5).score()
+	default:
+		lowerScore, found = mv.lower(2).score()
+	}
+	addLower(lowerScore, found, []string{"VC", "VI", "VA", "CR", "IR", "AR"}, cvss4DepthEQ3EQ6[mv[2]][mv[5]])
+
+	lowerScore, found = mv.lower(3).score()
+	addLower(lowerScore, found, []string{"SC", "SI", "SA"}, cvss4DepthEQ4[mv[3]])
+
+	// EQ5 only holds the exploit maturity so a vector is always at its highest severity
+	lowerScore, found = mv.lower(4).score()
+	addLower(lowerScore, found, nil, 1)
+
+	if lowerCount > 0 {
+		score -= total / float64(lowerCount)
+	}
+
+	return math.Round(math.Max(0, math.Min(score, 10))*10) / 10
+}
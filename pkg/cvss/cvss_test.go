@@ -0,0 +1,77 @@
+package cvss
+
+import "testing"
+
+func TestBaseScore(t *testing.T) {
+	tests := []struct {
+		vector string
+		score  float64
+		rating Rating
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8, RatingCritical},
+		{"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:H/I:H/A:H", 9.9, RatingCritical},
+		{"CVSS:3.0/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", 6.1, RatingMedium},
+		{"CVSS:3.1/AV:P/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N", 1.6, RatingLow},
+		{"CVSS:3.1/AV:L/AC:H/PR:H/UI:R/S:U/C:N/I:N/A:N", 0, RatingNone},
+		{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N", 9.3, RatingCritical},
+		{"CVSS:4.0/AV:N/AC:L/AT:N/PR:L/UI:N/VC:H/VI:L/VA:L/SC:N/SI:N/SA:N", 7.2, RatingHigh},
+		{"CVSS:4.0/AV:L/AC:L/AT:P/PR:L/UI:A/VC:L/VI:N/VA:N/SC:N/SI:N/SA:N", 1.0, RatingLow},
+		{"CVSS:4.0/AV:P/AC:H/AT:P/PR:H/UI:A/VC:N/VI:N/VA:N/SC:N/SI:N/SA:N", 0, RatingNone},
+		// threat and environmental metrics do not change the base score
+		{"CVSS:4.0/AV:A/AC:H/AT:N/PR:N/UI:P/VC:H/VI:H/VA:H/SC:H/SI:H/SA:H/E:U/CR:L", 8.9, RatingHigh},
+	}
+
+	for _, test := range tests {
+		t.Run(test.vector, func(t *testing.T) {
+			vector, err := Parse(test.vector)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if score := vector.BaseScore(); score != test.score {
+				t.Errorf("expected score %.1f, got %.1f", test.score, score)
+			}
+
+			if rating := vector.Rating(); rating != test.rating {
+				t.Errorf("expected rating %s, got %s", test.rating, rating)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, vector := range []string{
+		"",
+		"AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		"CVSS:2.0/AV:N/AC:L/Au:N/C:P/I:P/A:P",
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H",
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/A:L",
+		"CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/AT:N",
+		"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:R/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N",
+	} {
+		if _, err := Parse(vector); err == nil {
+			t.Errorf("expected '%s' to be invalid", vector)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	vector, err := Parse("CVSS:3.1/C:H/AV:N/AC:L/PR:N/UI:R/S:U/I:N/A:N/E:P")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if canonical := "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:U/C:H/I:N/A:N/E:P"; vector.String() != canonical {
+		t.Errorf("expected %s, got %s", canonical, vector.String())
+	}
+
+	metric, ok := vector.Metric("E")
+	if !ok || metric.String() != "Exploit Code Maturity: Proof-of-Concept" {
+		t.Errorf("unexpected explanation '%s'", metric)
+	}
+
+	if metrics := vector.Metrics(); len(metrics) != 9 || metrics[0].ValueName != "Network" {
+		t.Errorf("unexpected metrics %v", metrics)
+	}
+}
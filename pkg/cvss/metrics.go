@@ -0,0 +1,126 @@
+package cvss
+
+// metricValue is a single allowed value of a metric together with its name in the specification
+type metricValue struct {
+	Key  string
+	Name string
+}
+
+// metricDefinition describes a metric that may appear in a vector
+type metricDefinition struct {
+	Key    string
+	Name   string
+	Base   bool
+	Values []metricValue
+}
+
+func (d metricDefinition) value(key string) (metricValue, bool) {
+	for _, value := range d.Values {
+		if value.Key == key {
+			return value, true
+		}
+	}
+
+	return metricValue{}, false
+}
+
+var (
+	notDefined = metricValue{"X", "Not Defined"}
+
+	attackVector3 = []metricValue{{"N", "Network"}, {"A", "Adjacent Network"}, {"L", "Local"}, {"P", "Physical"}}
+	attackVector4 = []metricValue{{"N", "Network"}, {"A", "Adjacent"}, {"L", "Local"}, {"P", "Physical"}}
+	complexity    = []metricValue{{"L", "Low"}, {"H", "High"}}
+	privileges    = []metricValue{{"N", "None"}, {"L", "Low"}, {"H", "High"}}
+	interaction3  = []metricValue{{"N", "None"}, {"R", "Required"}}
+	interaction4  = []metricValue{{"N", "None"}, {"P", "Passive"}, {"A", "Active"}}
+	scope         = []metricValue{{"U", "Unchanged"}, {"C", "Changed"}}
+	impact        = []metricValue{{"H", "High"}, {"L", "Low"}, {"N", "None"}}
+	safetyImpact  = []metricValue{{"S", "Safety"}, {"H", "High"}, {"L", "Low"}, {"N", "None"}}
+	requirement   = []metricValue{notDefined, {"H", "High"}, {"M", "Medium"}, {"L", "Low"}}
+)
+
+// optional prepends the Not Defined value to the values of a base metric for its modified counterpart
+func optional(values []metricValue) []metricValue {
+	return append([]metricValue{notDefined}, values...)
+}
+
+// cvss3Metrics are the metrics of CVSS 3.0 and 3.1 vectors in specification order
+var cvss3Metrics = []metricDefinition{
+	{Key: "AV", Name: "Attack Vector", Base: true, Values: attackVector3},
+	{Key: "AC", Name: "Attack Complexity", Base: true, Values: complexity},
+	{Key: "PR", Name: "Privileges Required", Base: true, Values: privileges},
+	{Key: "UI", Name: "User Interaction", Base: true, Values: interaction3},
+	{Key: "S", Name: "Scope", Base: true, Values: scope},
+	{Key: "C", Name: "Confidentiality", Base: true, Values: impact},
+	{Key: "I", Name: "Integrity", Base: true, Values: impact},
+	{Key: "A", Name: "Availability", Base: true, Values: impact},
+
+	{Key: "E", Name: "Exploit Code Maturity", Values: []metricValue{
+		notDefined, {"H", "High"}, {"F", "Functional"}, {"P", "Proof-of-Concept"}, {"U", "Unproven"},
+	}},
+	{Key: "RL", Name: "Remediation Level", Values: []metricValue{
+		notDefined, {"U", "Unavailable"}, {"W", "Workaround"}, {"T", "Temporary Fix"}, {"O", "Official Fix"},
+	}},
+	{Key: "RC", Name: "Report Confidence", Values: []metricValue{
+		notDefined, {"C", "Confirmed"}, {"R", "Reasonable"}, {"U", "Unknown"},
+	}},
+
+	{Key: "CR", Name: "Confidentiality Requirement", Values: requirement},
+	{Key: "IR", Name: "Integrity Requirement", Values: requirement},
+	{Key: "AR", Name: "Availability Requirement", Values: requirement},
+	{Key: "MAV", Name: "Modified Attack Vector", Values: optional(attackVector3)},
+	{Key: "MAC", Name: "Modified Attack Complexity", Values: optional(complexity)},
+	{Key: "MPR", Name: "Modified Privileges Required", Values: optional(privileges)},
+	{Key: "MUI", Name: "Modified User Interaction", Values: optional(interaction3)},
+	{Key: "MS", Name: "Modified Scope", Values: optional(scope)},
+	{Key: "MC", Name: "Modified Confidentiality", Values: optional(impact)},
+	{Key: "MI", Name: "Modified Integrity", Values: optional(impact)},
+	{Key: "MA", Name: "Modified Availability", Values: optional(impact)},
+}
+
+// cvss4Metrics are the metrics of CVSS 4.0 vectors in specification order
+var cvss4Metrics = []metricDefinition{
+	{Key: "AV", Name: "Attack Vector", Base: true, Values: attackVector4},
+	{Key: "AC", Name: "Attack Complexity", Base: true, Values: complexity},
+	{Key: "AT", Name: "Attack Requirements", Base: true, Values: []metricValue{{"N", "None"}, {"P", "Present"}}},
+	{Key: "PR", Name: "Privileges Required", Base: true, Values: privileges},
+	{Key: "UI", Name: "User Interaction", Base: true, Values: interaction4},
+	{Key: "VC", Name: "Vulnerable System Confidentiality", Base: true, Values: impact},
+	{Key: "VI", Name: "Vulnerable System Integrity", Base: true, Values: impact},
+	{Key: "VA", Name: "Vulnerable System Availability", Base: true, Values: impact},
+	{Key: "SC", Name: "Subsequent System Confidentiality", Base: true, Values: impact},
+	{Key: "SI", Name: "Subsequent System Integrity", Base: true, Values: impact},
+	{Key: "SA", Name: "Subsequent System Availability", Base: true, Values: impact},
+
+	{Key: "E", Name: "Exploit Maturity", Values: []metricValue{
+		notDefined, {"A", "Attacked"}, {"P", "POC"}, {"U", "Unreported"},
+	}},
+
+	{Key: "CR", Name: "Confidentiality Requirement", Values: requirement},
+	{Key: "IR", Name: "Integrity Requirement", Values: requirement},
+	{Key: "AR", Name: "Availability Requirement", Values: requirement},
+	{Key: "MAV", Name: "Modified Attack Vector", Values: optional(attackVector4)},
+	{Key: "MAC", Name: "Modified Attack Complexity", Values: optional(complexity)},
+	{Key: "MAT", Name: "Modified Attack Requirements", Values: []metricValue{notDefined, {"N", "None"}, {"P", "Present"}}},
+	{Key: "MPR", Name: "Modified Privileges Required", Values: optional(privileges)},
+	{Key: "MUI", Name: "Modified User Interaction", Values: optional(interaction4)},
+	{Key: "MVC", Name: "Modified Vulnerable System Confidentiality", Values: optional(impact)},
+	{Key: "MVI", Name: "Modified Vulnerable System Integrity", Values: optional(impact)},
+	{Key: "MVA", Name: "Modified Vulnerable System Availability", Values: optional(impact)},
+	{Key: "MSC", Name: "Modified Subsequent System Confidentiality", Values: optional(impact)},
+	{Key: "MSI", Name: "Modified Subsequent System Integrity", Values: optional(safetyImpact)},
+	{Key: "MSA", Name: "Modified Subsequent System Availability", Values: optional(safetyImpact)},
+
+	{Key: "S", Name: "Safety", Values: []metricValue{notDefined, {"N", "Negligible"}, {"P", "Present"}}},
+	{Key: "AU", Name: "Automatable", Values: []metricValue{notDefined, {"N", "No"}, {"Y", "Yes"}}},
+	{Key: "R", Name: "Recovery", Values: []metricValue{
+		notDefined, {"A", "Automatic"}, {"U", "User"}, {"I", "Irrecoverable"},
+	}},
+	{Key: "V", Name: "Value Density", Values: []metricValue{notDefined, {"D", "Diffuse"}, {"C", "Concentrated"}}},
+	{Key: "RE", Name: "Vulnerability Response Effort", Values: []metricValue{
+		notDefined, {"L", "Low"}, {"M", "Moderate"}, {"H", "High"},
+	}},
+	{Key: "U", Name: "Provider Urgency", Values: []metricValue{
+		notDefined, {"Clear", "Clear"}, {"Green", "Green"}, {"Amber", "Amber"}, {"Red", "Red"},
+	}},
+}
@@ -0,0 +1,61 @@
+package cvss
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// Rating is the qualitative severity rating of a score, which is the same for CVSS 3.x and 4.0
+type Rating int
+
+const (
+	RatingNone Rating = iota
+	RatingLow
+	RatingMedium
+	RatingHigh
+	RatingCritical
+)
+
+var ratingNames = map[Rating]string{
+	RatingNone:     "None",
+	RatingLow:      "Low",
+	RatingMedium:   "Medium",
+	RatingHigh:     "High",
+	RatingCritical: "Critical",
+}
+
+// RatingOf returns the qualitative severity rating of the score
+func RatingOf(score float64) Rating {
+	switch {
+	case score >= 9.0:
+		return RatingCritical
+	case score >= 7.0:
+		return RatingHigh
+	case score >= 4.0:
+		return RatingMedium
+	case score > 0:
+		return RatingLow
+	default:
+		return RatingNone
+	}
+}
+
+// ParseRating returns the rating for its name, such as 'high'
+func ParseRating(name string) (Rating, error) {
+	for rating, ratingName := range ratingNames {
+		if strings.EqualFold(ratingName, strings.TrimSpace(name)) {
+			return rating, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown rating '%s'", name)
+}
+
+func (r Rating) String() string {
+	if name, ok := ratingNames[r]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Rating(%d)", int(r))
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"encoding/json"
+	intigriti "github.com/hazcod/go-intigriti/pkg/api"
+	"strings"
+)
+
+type EventType string
+
+const (
+	EventSubmissionCreated       EventType = "submission.created"
+	EventSubmissionUpdated       EventType = "submission.updated"
+	EventSubmissionStatusChanged EventType = "submission.status_changed"
+	EventSubmissionMessage       EventType = "submission.message"
+	EventProgramUpdated          EventType = "program.updated"
+	EventProgramStatusChanged    EventType = "program.status_changed"
+)
+
+// IsSubmission returns whether the event carries a submission
+func (t EventType) IsSubmission() bool {
+	return strings.HasPrefix(string(t), "submission.")
+}
+
+// IsProgram returns whether the event carries a program
+func (t EventType) IsProgram() bool {
+	return strings.HasPrefix(string(t), "program.")
+}
+
+// Event is the envelope of every webhook, Data holds the undecoded payload
+type Event struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	CreatedAt int             `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type SubmissionEvent struct {
+	Event
+	Submission intigriti.Submission
+}
+
+type ProgramEvent struct {
+	Event
+	Program intigriti.Program
+}
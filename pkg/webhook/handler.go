@@ -0,0 +1,246 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// how far the webhook timestamp may be from our clock
+	defaultTolerance = 5 * time.Minute
+	// the maximum webhook body we accept
+	maxBodyBytes = 1024 * 1024
+)
+
+type Config struct {
+	// Required: the secret configured for the webhook in Intigriti
+	Secret string
+
+	// Optional: how far the webhook timestamp may be from our clock, defaults to 5 minutes
+	Tolerance time.Duration
+
+	// Optional: logger instance
+	Logger *logrus.Logger
+}
+
+type SubmissionHandler func(ctx context.Context, event SubmissionEvent) error
+type ProgramHandler func(ctx context.Context, event ProgramEvent) error
+type EventHandler func(ctx context.Context, event Event) error
+
+// Handler is an http.Handler which verifies, decodes and dispatches Intigriti webhooks
+// callbacks run synchronously, a failing callback results in a 500 so the webhook is delivered again
+type Handler struct {
+	secret    []byte
+	tolerance time.Duration
+	logger    *logrus.Logger
+	now       func() time.Time
+
+	mu                 sync.RWMutex
+	submissionHandlers map[EventType][]SubmissionHandler
+	programHandlers    map[EventType][]ProgramHandler
+	eventHandlers      []EventHandler
+
+	deliveries *deliveryCache
+}
+
+// New creates a webhook handler, register callbacks before serving it
+func New(cfg Config) (*Handler, error) {
+	if cfg.Secret == "" {
+		return nil, errors.New("no webhook secret provided")
+	}
+
+	h := &Handler{
+		secret:             []byte(cfg.Secret),
+		tolerance:          cfg.Tolerance,
+		logger:             cfg.Logger,
+		now:                time.Now,
+		submissionHandlers: make(map[EventType][]SubmissionHandler),
+		programHandlers:    make(map[EventType][]ProgramHandler),
+	}
+
+	if h.tolerance <= 0 {
+		h.tolerance = defaultTolerance
+	}
+
+	if h.logger == nil {
+		h.logger = logrus.New()
+	}
+
+	// a delivery can only be replayed while its timestamp is within tolerance
+	h.deliveries = newDeliveryCache(2 * h.tolerance)
+
+	return h, nil
+}
+
+// OnSubmission registers a callback for the given submission event type
+func (h *Handler) OnSubmission(eventType EventType, fn SubmissionHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.submissionHandlers[eventType] = append(h.submissionHandlers[eventType], fn)
+}
+
+// OnProgram registers a callback for the given program event type
+func (h *Handler) OnProgram(eventType EventType, fn ProgramHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.programHandlers[eventType] = append(h.programHandlers[eventType], fn)
+}
+
+// OnEvent registers a callback for every verified event, including event types unknown to the SDK
+func (h *Handler) OnEvent(fn EventHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.eventHandlers = append(h.eventHandlers, fn)
+}
+
+// ServeHTTP verifies the webhook signature, rejects replays and dispatches the event to the registered callbacks
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		h.logger.WithError(err).Warn("could not read webhook body")
+
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		} else {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+
+		return
+	}
+
+	signature := r.Header.Get(SignatureHeader)
+	if err := Verify(h.secret, signature, r.Header.Get(TimestampHeader), body, h.tolerance, h.now()); err != nil {
+		h.logger.WithError(err).Warn("rejected webhook")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.WithError(err).Warn("could not decode webhook")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// replays are detected on the signed event identifier, the delivery header is not covered by the signature
+	// fall back to the signature for events without identifier, it is unique per timestamp and body
+	deliveryID := event.ID
+	if deliveryID == "" {
+		deliveryID = signature
+	}
+
+	logger := h.logger.WithField("delivery", r.Header.Get(DeliveryHeader)).WithField("event", deliveryID).WithField("type", event.Type)
+
+	if !h.deliveries.claim(deliveryID, h.now()) {
+		logger.WithError(ErrReplayed).Warn("rejected webhook")
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), event); err != nil {
+		// allow the delivery to be retried
+		h.deliveries.release(deliveryID)
+
+		logger.WithError(err).Error("webhook callback failed")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Debug("processed webhook")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) dispatch(ctx context.Context, event Event) error {
+	h.mu.RLock()
+	submissionHandlers := h.submissionHandlers[event.Type]
+	programHandlers := h.programHandlers[event.Type]
+	eventHandlers := h.eventHandlers
+	h.mu.RUnlock()
+
+	for _, fn := range eventHandlers {
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	if len(submissionHandlers) > 0 {
+		submissionEvent := SubmissionEvent{Event: event}
+		if err := json.Unmarshal(event.Data, &submissionEvent.Submission); err != nil {
+			return errors.Wrap(err, "could not decode submission")
+		}
+
+		for _, fn := range submissionHandlers {
+			if err := fn(ctx, submissionEvent); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(programHandlers) > 0 {
+		programEvent := ProgramEvent{Event: event}
+		if err := json.Unmarshal(event.Data, &programEvent.Program); err != nil {
+			return errors.Wrap(err, "could not decode program")
+		}
+
+		for _, fn := range programHandlers {
+			if err := fn(ctx, programEvent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// deliveryCache remembers processed deliveries for as long as they could be replayed
+type deliveryCache struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	claims map[string]time.Time
+}
+
+func newDeliveryCache(ttl time.Duration) *deliveryCache {
+	return &deliveryCache{ttl: ttl, claims: make(map[string]time.Time)}
+}
+
+// claim marks the delivery as processed, returning false if it already was
+func (c *deliveryCache) claim(id string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for claimed, at := range c.claims {
+		if now.Sub(at) > c.ttl {
+			delete(c.claims, claimed)
+		}
+	}
+
+	if _, found := c.claims[id]; found {
+		return false
+	}
+
+	c.claims[id] = now
+
+	return true
+}
+
+func (c *deliveryCache) release(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.claims, id)
+}
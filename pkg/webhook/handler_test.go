@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testSecret = "s3cr3t"
+	testBody   = `{"id":"1","type":"submission.created","createdAt":1700000000,"data":{"code":"ACME-1","title":"XSS"}}`
+)
+
+func newTestRequest(body, signature string, timestamp time.Time, delivery string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	req.Header.Set(SignatureHeader, signature)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(DeliveryHeader, delivery)
+	return req
+}
+
+func TestHandler(t *testing.T) {
+	handler, err := New(Config{Secret: testSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received []string
+	handler.OnSubmission(EventSubmissionCreated, func(_ context.Context, event SubmissionEvent) error {
+		received = append(received, event.Submission.Code)
+		return nil
+	})
+
+	now := time.Now()
+	validSignature := Sign([]byte(testSecret), now, []byte(testBody))
+
+	tests := []struct {
+		name     string
+		request  *http.Request
+		expected int
+	}{
+		{"valid", newTestRequest(testBody, validSignature, now, "a"), http.StatusNoContent},
+		{"replayed", newTestRequest(testBody, validSignature, now, "a"), http.StatusConflict},
+		{"replayed with other delivery", newTestRequest(testBody, validSignature, now, "forged"), http.StatusConflict},
+		{"resent with new signature", newTestRequest(testBody, Sign([]byte(testSecret), now.Add(time.Second), []byte(testBody)), now.Add(time.Second), "f"), http.StatusConflict},
+		{"wrong secret", newTestRequest(testBody, Sign([]byte("other"), now, []byte(testBody)), now, "b"), http.StatusUnauthorized},
+		{"tampered body", newTestRequest(testBody+" ", validSignature, now, "c"), http.StatusUnauthorized},
+		{"expired", newTestRequest(testBody, Sign([]byte(testSecret), now.Add(-time.Hour), []byte(testBody)), now.Add(-time.Hour), "d"), http.StatusUnauthorized},
+		{"missing signature", newTestRequest(testBody, "", now, "e"), http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, test.request)
+
+		if recorder.Code != test.expected {
+			t.Errorf("%s: got status %d, expected %d", test.name, recorder.Code, test.expected)
+		}
+	}
+
+	if len(received) != 1 || received[0] != "ACME-1" {
+		t.Errorf("expected a single ACME-1 submission, got %v", received)
+	}
+}
+
+func TestHandlerBodyTooLarge(t *testing.T) {
+	handler, err := New(Config{Secret: testSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := strings.Repeat("a", maxBodyBytes+1)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newTestRequest(body, Sign([]byte(testSecret), time.Now(), []byte(body)), time.Now(), "a"))
+
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got status %d, expected %d", recorder.Code, http.StatusRequestEntityTooLarge)
+	}
+}
@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the timestamp and body, prefixed with 'sha256='
+	SignatureHeader = "X-Intigriti-Signature"
+	// TimestampHeader holds the unix timestamp at which the webhook was sent
+	TimestampHeader = "X-Intigriti-Timestamp"
+	// DeliveryHeader holds the unique identifier of the delivery, retries have the same identifier
+	// it is not covered by the signature so it is only logged, replays are detected on the signed event identifier
+	DeliveryHeader = "X-Intigriti-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidTimestamp = errors.New("webhook timestamp outside of tolerance")
+	ErrReplayed         = errors.New("webhook delivery was already processed")
+)
+
+// Sign returns the signature header value for the webhook body sent at the given time
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of the webhook body and whether it was sent within the tolerance of now
+// the comparison is done in constant time
+func Verify(secret []byte, signature, timestamp string, body []byte, tolerance time.Duration, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	sentAt := time.Unix(unix, 0)
+	if sentAt.Before(now.Add(-tolerance)) || sentAt.After(now.Add(tolerance)) {
+		return ErrInvalidTimestamp
+	}
+
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+
+	expected := Sign(secret, sentAt, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}